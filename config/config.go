@@ -23,18 +23,46 @@ type Config struct {
 	AllowedImageTypes []string
 	FrontendURL       string
 	Environment       string
+
+	RecommendationCacheTTL time.Duration
+	ResumeMaxSize          int64
+	ResumeVersionsKept     int
+	AccountDeletionGrace   time.Duration
+	ProfileNudgeInterval   time.Duration
+	GitHubAPIBaseURL       string
+	GitHubToken            string
+	RepoMetadataRefresh    time.Duration
+	ProjectViewWindow      time.Duration
+	ProjectRankingInterval time.Duration
+	JobExpiryReminder      time.Duration
+	JobExtendPeriod        time.Duration
+	ReferralOpenLimit      int
+	SalaryMinReports       int
+	SalaryHashSecret       string
 }
 
 func GetConfig() *Config {
 	jwtExpiration, _ := time.ParseDuration(getEnv("JWT_EXPIRATION", "24h"))
 	refreshExpiration, _ := time.ParseDuration(getEnv("REFRESH_EXPIRATION", "168h"))
 	rateLimitWindow, _ := time.ParseDuration(getEnv("RATE_LIMIT_WINDOW", "1m"))
+	recommendationCacheTTL, _ := time.ParseDuration(getEnv("RECOMMENDATION_CACHE_TTL", "6h"))
+	accountDeletionGrace, _ := time.ParseDuration(getEnv("ACCOUNT_DELETION_GRACE_PERIOD", "720h")) // 30 days
+	profileNudgeInterval, _ := time.ParseDuration(getEnv("PROFILE_NUDGE_INTERVAL", "168h"))        // 7 days
+	repoMetadataRefresh, _ := time.ParseDuration(getEnv("REPO_METADATA_REFRESH_INTERVAL", "24h"))
+	projectViewWindow, _ := time.ParseDuration(getEnv("PROJECT_VIEW_DEDUPE_WINDOW", "30m"))
+	projectRankingInterval := getPositiveDuration("PROJECT_RANKING_INTERVAL", 15*time.Minute)
+	jobExpiryReminder, _ := time.ParseDuration(getEnv("JOB_EXPIRY_REMINDER", "72h")) // 3 days
+	jobExtendPeriod, _ := time.ParseDuration(getEnv("JOB_EXTEND_PERIOD", "720h"))    // 30 days
 
 	smtpPort, _ := strconv.Atoi(getEnv("SMTP_PORT", "587"))
 	rateLimitLogin, _ := strconv.Atoi(getEnv("RATE_LIMIT_LOGIN", "5"))
 	rateLimitRegister, _ := strconv.Atoi(getEnv("RATE_LIMIT_REGISTER", "3"))
 	rateLimitRefresh, _ := strconv.Atoi(getEnv("RATE_LIMIT_REFRESH", "10"))
-	maxFileSize, _ := strconv.ParseInt(getEnv("MAX_FILE_SIZE", "5242880"), 10, 64) // 5MB
+	maxFileSize, _ := strconv.ParseInt(getEnv("MAX_FILE_SIZE", "5242880"), 10, 64)      // 5MB
+	resumeMaxSize, _ := strconv.ParseInt(getEnv("RESUME_MAX_SIZE", "10485760"), 10, 64) // 10MB
+	resumeVersionsKept, _ := strconv.Atoi(getEnv("RESUME_VERSIONS_KEPT", "3"))
+	referralOpenLimit, _ := strconv.Atoi(getEnv("REFERRAL_OPEN_LIMIT", "3"))
+	salaryMinReports, _ := strconv.Atoi(getEnv("SALARY_INSIGHTS_MIN_REPORTS", "5"))
 
 	return &Config{
 		JWTSecret:         getEnv("JWT_SECRET", "your-secret-key"),
@@ -53,6 +81,22 @@ func GetConfig() *Config {
 		AllowedImageTypes: []string{"image/jpeg", "image/png", "image/gif", "image/webp"},
 		FrontendURL:       getEnv("FRONTEND_URL", "http://localhost:3000"),
 		Environment:       getEnv("ENVIRONMENT", "test"),
+
+		RecommendationCacheTTL: recommendationCacheTTL,
+		ResumeMaxSize:          resumeMaxSize,
+		ResumeVersionsKept:     resumeVersionsKept,
+		AccountDeletionGrace:   accountDeletionGrace,
+		ProfileNudgeInterval:   profileNudgeInterval,
+		GitHubAPIBaseURL:       getEnv("GITHUB_API_BASE_URL", "https://api.github.com"),
+		GitHubToken:            getEnv("GITHUB_TOKEN", ""),
+		RepoMetadataRefresh:    repoMetadataRefresh,
+		ProjectViewWindow:      projectViewWindow,
+		ProjectRankingInterval: projectRankingInterval,
+		JobExpiryReminder:      jobExpiryReminder,
+		JobExtendPeriod:        jobExtendPeriod,
+		ReferralOpenLimit:      referralOpenLimit,
+		SalaryMinReports:       salaryMinReports,
+		SalaryHashSecret:       getEnv("SALARY_HASH_SECRET", ""),
 	}
 }
 
@@ -62,3 +106,13 @@ func getEnv(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+// getPositiveDuration parses a duration, falling back to the default when the
+// value is malformed or not positive
+func getPositiveDuration(key string, defaultValue time.Duration) time.Duration {
+	duration, err := time.ParseDuration(getEnv(key, ""))
+	if err != nil || duration <= 0 {
+		return defaultValue
+	}
+	return duration
+}
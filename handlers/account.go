@@ -0,0 +1,580 @@
+package handlers
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"ete-alumni-portal/config"
+	"ete-alumni-portal/middleware"
+	"ete-alumni-portal/models"
+	"ete-alumni-portal/utils"
+)
+
+// userDataCollection describes where a collection references a user and what
+// happens to those documents when the account is exported or deleted.
+type userDataCollection struct {
+	name       string
+	fields     []string // fields holding the user's ID
+	emailField string   // field holding the user's email, if any
+	hashField  string   // field holding the user's salarySubmitterHash, if any
+	pulls      []string // arrays of embedded entries keyed by user_id; entries are pulled, not the document deleted
+	export     bool     // include in the data export
+	purge      bool     // delete on account deletion; otherwise kept against the anonymized user
+}
+
+// userDataCollections lists every collection holding personal data. New
+// collections that reference users must be added here so they are covered by
+// both the data export and account deletion.
+var userDataCollections = []userDataCollection{
+	{name: "projects", fields: []string{"author_id"}, pulls: []string{"contributors"}, export: true, purge: true},
+	{name: "project_likes", fields: []string{"user_id"}, export: true, purge: true},
+	{name: "project_comments", fields: []string{"author_id"}, export: true, purge: true},
+	{name: "comment_reports", fields: []string{"reporter_id"}, export: true, purge: true},
+	{name: "project_reviews", fields: []string{"reviewer_id"}, export: true, purge: false},
+	{name: "project_media", fields: []string{"uploaded_by"}, export: true, purge: false},
+	{name: "project_views", fields: []string{"viewer_id"}, export: true, purge: true},
+	{name: "project_versions", fields: []string{"edited_by"}, export: true, purge: false},
+	{name: "project_showcases", fields: []string{"created_by"}, export: true, purge: false},
+	{name: "jobs", fields: []string{"posted_by"}, export: true, purge: true},
+	{name: "job_interests", fields: []string{"user_id"}, export: true, purge: true},
+	{name: "job_applications", fields: []string{"user_id"}, export: true, purge: true},
+	{name: "saved_job_searches", fields: []string{"user_id"}, export: true, purge: true},
+	{name: "referral_requests", fields: []string{"student_id", "alumnus_id"}, export: true, purge: true},
+	{name: "salary_reports", hashField: "submitter_hash", export: true, purge: true},
+	{name: "messages", fields: []string{"sender_id", "recipient_id"}, export: true, purge: true},
+	{name: "event_rsvps", fields: []string{"user_id"}, export: true, purge: true},
+	{name: "notifications", fields: []string{"user_id"}, export: true, purge: true},
+	{name: "gallery", fields: []string{"uploaded_by"}, export: true, purge: false},
+	{name: "skill_endorsements", fields: []string{"user_id", "endorser_id"}, export: true, purge: true},
+	{name: "connections", fields: []string{"requester_id", "addressee_id"}, export: true, purge: true},
+	{name: "follows", fields: []string{"follower_id", "followee_id"}, export: true, purge: true},
+	{name: "mentor_profiles", fields: []string{"user_id"}, export: true, purge: true},
+	{name: "mentorships", fields: []string{"mentor_id", "mentee_id"}, export: true, purge: true},
+	{name: "resumes", fields: []string{"user_id"}, export: true, purge: true},
+	{name: "cohort_announcements", fields: []string{"author_id"}, export: true, purge: true},
+	{name: "cohort_representatives", fields: []string{"user_id"}, export: true, purge: true},
+	{name: "profile_views", fields: []string{"viewer_id", "viewed_id"}, export: true, purge: true},
+	{name: "user_preferences", fields: []string{"user_id"}, export: true, purge: true},
+	{name: "people_recommendations", fields: []string{"user_id"}, export: false, purge: true},
+	{name: "refresh_tokens", fields: []string{"user_id"}, export: false, purge: true},
+	{name: "otp_verifications", emailField: "email", export: false, purge: true},
+	{name: "email_logs", emailField: "to_email", export: false, purge: true},
+}
+
+func (d userDataCollection) filter(user *models.User) bson.M {
+	or := []bson.M{}
+	for _, field := range d.fields {
+		or = append(or, bson.M{field: user.ID})
+	}
+	if d.emailField != "" {
+		or = append(or, bson.M{d.emailField: user.Email})
+	}
+	if d.hashField != "" {
+		or = append(or, bson.M{d.hashField: salarySubmitterHash(user.ID)})
+	}
+	for _, field := range d.pulls {
+		or = append(or, bson.M{field + ".user_id": user.ID})
+	}
+	return bson.M{"$or": or}
+}
+
+type AccountHandler struct{}
+
+func NewAccountHandler() *AccountHandler {
+	return &AccountHandler{}
+}
+
+// ExportData returns a ZIP archive with everything the portal stores about the user
+func (h *AccountHandler) ExportData(c *fiber.Ctx) error {
+	userID := middleware.GetUserID(c)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	var user models.User
+	if err := config.GetCollection("users").FindOne(ctx, bson.M{"_id": userID}).Decode(&user); err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "User not found",
+		})
+	}
+
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+
+	writeJSON := func(name string, data interface{}) error {
+		w, err := archive.Create(name)
+		if err != nil {
+			return err
+		}
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(data)
+	}
+
+	profile := fiber.Map{
+		"profile": user,
+		"privacy": user.Privacy,
+	}
+	if err := writeJSON("profile.json", profile); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to build export",
+		})
+	}
+
+	var files []string
+	if strings.HasPrefix(user.AvatarURL, "/avatars/") {
+		files = append(files, filepath.Join("./public/avatars", filepath.Base(user.AvatarURL)))
+	}
+
+	for _, collection := range userDataCollections {
+		if !collection.export {
+			continue
+		}
+
+		cursor, err := config.GetCollection(collection.name).Find(ctx, collection.filter(&user))
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error":   true,
+				"message": "Failed to export " + collection.name,
+			})
+		}
+
+		documents := []bson.M{}
+		err = cursor.All(ctx, &documents)
+		cursor.Close(ctx)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error":   true,
+				"message": "Failed to export " + collection.name,
+			})
+		}
+
+		// Views of the user's profile are exported without the viewer, as
+		// viewers may have hidden their profile views
+		if collection.name == "profile_views" {
+			for _, document := range documents {
+				if document["viewer_id"] != user.ID {
+					delete(document, "viewer_id")
+				}
+			}
+		}
+
+		if err := writeJSON(collection.name+".json", documents); err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error":   true,
+				"message": "Failed to build export",
+			})
+		}
+
+		files = append(files, userFiles(collection.name, documents)...)
+	}
+
+	// Uploaded files are copied into the archive under files/
+	for _, path := range files {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		w, err := archive.Create("files/" + filepath.Base(path))
+		if err != nil {
+			continue
+		}
+		w.Write(content)
+	}
+
+	if err := archive.Close(); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to build export",
+		})
+	}
+
+	c.Set(fiber.HeaderContentType, "application/zip")
+	c.Set(fiber.HeaderContentDisposition, `attachment; filename="alumni-portal-data-`+time.Now().Format("2006-01-02")+`.zip"`)
+	c.Set(fiber.HeaderCacheControl, "private, no-store")
+	return c.Send(buf.Bytes())
+}
+
+// userFiles returns the paths on disk of files referenced by exported documents
+func userFiles(collection string, documents []bson.M) []string {
+	var files []string
+	for _, document := range documents {
+		switch collection {
+		case "resumes":
+			if name, ok := document["stored_name"].(string); ok {
+				files = append(files, filepath.Join(resumeDir, filepath.Base(name)))
+			}
+		case "project_media":
+			if name, ok := document["stored_name"].(string); ok {
+				files = append(files, filepath.Join(projectMediaDir, filepath.Base(name)))
+			}
+		case "gallery":
+			if url, ok := document["image_url"].(string); ok && strings.HasPrefix(url, "/gallery/") {
+				files = append(files, filepath.Join("./public/gallery", filepath.Base(url)))
+			}
+		}
+	}
+	return files
+}
+
+// RequestDeletion schedules the account for deletion after the grace period
+func (h *AccountHandler) RequestDeletion(c *fiber.Ctx) error {
+	userID := middleware.GetUserID(c)
+
+	var req models.DeleteAccountRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid request body",
+		})
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": err.Error(),
+		})
+	}
+
+	collection := config.GetCollection("users")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var user models.User
+	if err := collection.FindOne(ctx, bson.M{"_id": userID}).Decode(&user); err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "User not found",
+		})
+	}
+
+	if !utils.CheckPassword(req.Password, user.PasswordHash) {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid password",
+		})
+	}
+
+	if user.DeletionScheduledAt != nil {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error":   true,
+			"message": "Account deletion is already scheduled",
+			"data": fiber.Map{
+				"deletion_scheduled_at": user.DeletionScheduledAt,
+			},
+		})
+	}
+
+	scheduledAt := time.Now().Add(config.GetConfig().AccountDeletionGrace)
+	_, err := collection.UpdateOne(ctx, bson.M{"_id": userID}, bson.M{
+		"$set": bson.M{
+			"deletion_scheduled_at": scheduledAt,
+			"updated_at":            time.Now(),
+		},
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to schedule account deletion",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"error":   false,
+		"message": "Account scheduled for deletion. You can cancel this until the date below.",
+		"data": fiber.Map{
+			"deletion_scheduled_at": scheduledAt,
+		},
+	})
+}
+
+func (h *AccountHandler) CancelDeletion(c *fiber.Ctx) error {
+	userID := middleware.GetUserID(c)
+
+	collection := config.GetCollection("users")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	result, err := collection.UpdateOne(ctx, bson.M{
+		"_id":                   userID,
+		"deletion_scheduled_at": bson.M{"$exists": true},
+	}, bson.M{
+		"$unset": bson.M{"deletion_scheduled_at": ""},
+		"$set":   bson.M{"updated_at": time.Now()},
+	})
+	if err != nil || result.MatchedCount == 0 {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "No pending account deletion",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"error":   false,
+		"message": "Account deletion cancelled",
+	})
+}
+
+// ProcessAccountDeletions periodically deletes accounts whose grace period has passed
+func ProcessAccountDeletions() {
+	ticker := time.NewTicker(1 * time.Hour)
+	defer ticker.Stop()
+
+	for {
+		deleteDueAccounts()
+		<-ticker.C
+	}
+}
+
+func deleteDueAccounts() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	cursor, err := config.GetCollection("users").Find(ctx, bson.M{
+		"deletion_scheduled_at": bson.M{"$lte": time.Now()},
+	})
+	if err != nil {
+		log.Printf("Failed to fetch accounts due for deletion: %v", err)
+		return
+	}
+	defer cursor.Close(ctx)
+
+	var users []models.User
+	if err = cursor.All(ctx, &users); err != nil {
+		log.Printf("Failed to decode accounts due for deletion: %v", err)
+		return
+	}
+
+	for i := range users {
+		if err := purgeUserData(ctx, &users[i]); err != nil {
+			log.Printf("Failed to delete account %s: %v", users[i].ID.Hex(), err)
+			continue
+		}
+		log.Printf("Deleted account %s", users[i].ID.Hex())
+	}
+}
+
+// transferTeamProjects hands each of the user's projects that still has
+// accepted contributors to the contributor who joined first, so deleting the
+// author does not take the team's work with it.
+func transferTeamProjects(ctx context.Context, userID primitive.ObjectID) error {
+	cursor, err := config.GetCollection("projects").Find(ctx, bson.M{
+		"author_id":    userID,
+		"contributors": bson.M{"$elemMatch": bson.M{"status": models.ContributorAccepted}},
+	})
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	var projects []models.Project
+	if err = cursor.All(ctx, &projects); err != nil {
+		return err
+	}
+
+	for _, project := range projects {
+		var owner *models.ProjectContributor
+		for i := range project.Contributors {
+			contributor := &project.Contributors[i]
+			if contributor.Status != models.ContributorAccepted || contributor.UserID == userID {
+				continue
+			}
+			if owner == nil || (contributor.RespondedAt != nil && owner.RespondedAt != nil && contributor.RespondedAt.Before(*owner.RespondedAt)) {
+				owner = contributor
+			}
+		}
+		if owner == nil {
+			continue
+		}
+
+		_, err := config.GetCollection("projects").UpdateOne(ctx, bson.M{
+			"_id":       project.ID,
+			"author_id": userID,
+		}, bson.M{
+			"$set":  bson.M{"author_id": owner.UserID, "updated_at": time.Now()},
+			"$pull": bson.M{"contributors": bson.M{"user_id": owner.UserID}},
+		})
+		if err != nil {
+			return err
+		}
+
+		createNotification(ctx, owner.UserID,
+			"Project Ownership Transferred",
+			"You are now the owner of "+project.Title+" because its author deleted their account",
+			models.NotificationProjectTeam,
+			&project.ID,
+			"project",
+		)
+	}
+	return nil
+}
+
+// releaseCounts decrements a denormalized counter on the target documents
+// whose targetKey the matched rows point at through refField. It runs before
+// those rows are deleted.
+func releaseCounts(ctx context.Context, source string, match bson.M, refField, target, targetKey, counter string) {
+	cursor, err := config.GetCollection(source).Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$group", Value: bson.M{"_id": "$" + refField, "count": bson.M{"$sum": 1}}}},
+	})
+	if err != nil {
+		return
+	}
+	defer cursor.Close(ctx)
+
+	var counts []struct {
+		ID    primitive.ObjectID `bson:"_id"`
+		Count int                `bson:"count"`
+	}
+	if cursor.All(ctx, &counts) != nil {
+		return
+	}
+	for _, count := range counts {
+		config.GetCollection(target).UpdateOne(ctx, bson.M{targetKey: count.ID}, bson.M{
+			"$inc": bson.M{counter: -count.Count},
+		})
+	}
+}
+
+// purgeUserData removes the user's data from every collection and anonymizes
+// the user document so that kept references no longer identify them.
+func purgeUserData(ctx context.Context, user *models.User) error {
+	for _, collection := range userDataCollections {
+		if !collection.purge {
+			continue
+		}
+
+		// Files on disk go with their documents
+		if collection.name == "resumes" {
+			cursor, err := config.GetCollection("resumes").Find(ctx, collection.filter(user))
+			if err == nil {
+				documents := []bson.M{}
+				if cursor.All(ctx, &documents) == nil {
+					for _, path := range userFiles("resumes", documents) {
+						os.Remove(path)
+					}
+				}
+				cursor.Close(ctx)
+			}
+		}
+
+		// Attachments and history go with the user's own projects; team
+		// projects are handed over instead of deleted
+		if collection.name == "projects" {
+			if err := transferTeamProjects(ctx, user.ID); err != nil {
+				return err
+			}
+
+			projectIDs, err := config.GetCollection("projects").Distinct(ctx, "_id", bson.M{"author_id": user.ID})
+			if err == nil {
+				for _, value := range projectIDs {
+					if projectID, ok := value.(primitive.ObjectID); ok {
+						deleteProjectMedia(ctx, projectID)
+						config.GetCollection("project_versions").DeleteMany(ctx, bson.M{"project_id": projectID})
+					}
+				}
+			}
+		}
+
+		// Applications to the user's jobs go with the jobs
+		if collection.name == "jobs" {
+			jobIDs, err := config.GetCollection("jobs").Distinct(ctx, "_id", bson.M{"posted_by": user.ID})
+			if err == nil && len(jobIDs) > 0 {
+				config.GetCollection("job_applications").DeleteMany(ctx, bson.M{"job_id": bson.M{"$in": jobIDs}})
+			}
+		}
+
+		// Counters on other documents drop with the rows behind them
+		switch collection.name {
+		case "project_likes":
+			releaseCounts(ctx, "project_likes", bson.M{"user_id": user.ID}, "project_id", "projects", "_id", "likes_count")
+		case "project_comments":
+			releaseCounts(ctx, "project_comments", bson.M{
+				"author_id": user.ID,
+				"status":    bson.M{"$ne": models.CommentDeleted},
+			}, "project_id", "projects", "_id", "comments_count")
+		case "skill_endorsements":
+			releaseCounts(ctx, "skill_endorsements", bson.M{"endorser_id": user.ID}, "user_id", "users", "_id", "endorsements_count")
+		case "referral_requests":
+			releaseCounts(ctx, "referral_requests", bson.M{
+				"alumnus_id": user.ID,
+				"status":     models.ReferralPending,
+			}, "student_id", "users", "_id", "open_referrals")
+		case "job_applications":
+			releaseCounts(ctx, "job_applications", bson.M{
+				"user_id": user.ID,
+				"status":  bson.M{"$ne": models.ApplicationWithdrawn},
+			}, "job_id", "jobs", "_id", "applicants_count")
+		case "mentorships":
+			releaseCounts(ctx, "mentorships", bson.M{
+				"mentee_id": user.ID,
+				"status":    models.MentorshipActive,
+			}, "mentor_id", "mentor_profiles", "user_id", "active_mentees")
+		}
+
+		// Drop embedded entries first so shared documents are kept
+		for _, field := range collection.pulls {
+			_, err := config.GetCollection(collection.name).UpdateMany(ctx,
+				bson.M{field + ".user_id": user.ID},
+				bson.M{"$pull": bson.M{field: bson.M{"user_id": user.ID}}},
+			)
+			if err != nil {
+				return err
+			}
+		}
+
+		if _, err := config.GetCollection(collection.name).DeleteMany(ctx, collection.filter(user)); err != nil {
+			return err
+		}
+	}
+
+	if strings.HasPrefix(user.AvatarURL, "/avatars/") {
+		os.Remove(filepath.Join("./public/avatars", filepath.Base(user.AvatarURL)))
+	}
+
+	now := time.Now()
+	_, err := config.GetCollection("users").UpdateOne(ctx, bson.M{"_id": user.ID}, bson.M{
+		"$set": bson.M{
+			"name":               "Deleted User",
+			"email":              "deleted-" + user.ID.Hex() + "@deleted.invalid",
+			"password_hash":      "",
+			"endorsements_count": 0,
+			"open_referrals":     0,
+			"is_active":          false,
+			"is_verified":        false,
+			"deleted_at":         now,
+			"updated_at":         now,
+		},
+		"$unset": bson.M{
+			"student_id":            "",
+			"graduation_year":       "",
+			"cgpa":                  "",
+			"company":               "",
+			"position":              "",
+			"location":              "",
+			"experience":            "",
+			"skills":                "",
+			"bio":                   "",
+			"github_url":            "",
+			"linkedin_url":          "",
+			"avatar_url":            "",
+			"privacy":               "",
+			"deletion_scheduled_at": "",
+		},
+	})
+	return err
+}
@@ -24,6 +24,8 @@ type AnalyticsData struct {
 	EventStats   EventStats   `json:"event_stats"`
 	GrowthStats  GrowthStats  `json:"growth_stats"`
 	ActivityLog  []Activity   `json:"activity_log"`
+
+	CompletenessStats CompletenessStats `json:"completeness_stats"`
 }
 
 type UserStats struct {
@@ -63,6 +65,17 @@ type GrowthStats struct {
 	EventsGrowth   float64 `json:"events_growth"`
 }
 
+type CompletenessStats struct {
+	AverageScore   float64              `json:"average_score"`
+	BelowThreshold int64                `json:"below_threshold"`
+	Distribution   []CompletenessBucket `json:"distribution"`
+}
+
+type CompletenessBucket struct {
+	Range string `json:"range"`
+	Count int64  `json:"count"`
+}
+
 type Activity struct {
 	Type      string    `json:"type"`
 	Message   string    `json:"message"`
@@ -138,6 +151,16 @@ func (h *AnalyticsHandler) GetDashboardAnalytics(c *fiber.Ctx) error {
 	}
 	analytics.ActivityLog = activityLog
 
+	// Get profile completeness distribution
+	completenessStats, err := h.getCompletenessStats(ctx)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to fetch profile completeness statistics",
+		})
+	}
+	analytics.CompletenessStats = completenessStats
+
 	return c.JSON(fiber.Map{
 		"error": false,
 		"data":  analytics,
@@ -194,6 +217,65 @@ func (h *AnalyticsHandler) getUserStats(ctx context.Context) (UserStats, error)
 	return stats, nil
 }
 
+func (h *AnalyticsHandler) getCompletenessStats(ctx context.Context) (CompletenessStats, error) {
+	collection := config.GetCollection("users")
+
+	stats := CompletenessStats{Distribution: []CompletenessBucket{}}
+	filter := bson.M{"is_verified": true, "is_active": true}
+
+	// Scores are grouped into 20-point buckets
+	cursor, err := collection.Aggregate(ctx, bson.A{
+		bson.M{"$match": filter},
+		bson.M{"$bucket": bson.M{
+			"groupBy":    bson.M{"$ifNull": bson.A{"$profile_completeness", 0}},
+			"boundaries": bson.A{0, 20, 40, 60, 80, 101},
+			"default":    "other",
+			"output": bson.M{
+				"count": bson.M{"$sum": 1},
+				"total": bson.M{"$sum": bson.M{"$ifNull": bson.A{"$profile_completeness", 0}}},
+			},
+		}},
+	})
+	if err != nil {
+		return stats, err
+	}
+	defer cursor.Close(ctx)
+
+	var buckets []struct {
+		LowerBound interface{} `bson:"_id"`
+		Count      int64       `bson:"count"`
+		Total      int64       `bson:"total"`
+	}
+	if err = cursor.All(ctx, &buckets); err != nil {
+		return stats, err
+	}
+
+	labels := map[int64]string{0: "0-19", 20: "20-39", 40: "40-59", 60: "60-79", 80: "80-100"}
+	var users, total int64
+	for _, bucket := range buckets {
+		var lower int64
+		switch bound := bucket.LowerBound.(type) {
+		case int32:
+			lower = int64(bound)
+		case int64:
+			lower = bound
+		default:
+			continue
+		}
+		stats.Distribution = append(stats.Distribution, CompletenessBucket{Range: labels[lower], Count: bucket.Count})
+		users += bucket.Count
+		total += bucket.Total
+		if lower < models.ProfileCompletenessThreshold {
+			stats.BelowThreshold += bucket.Count
+		}
+	}
+	if users > 0 {
+		stats.AverageScore = float64(total) / float64(users)
+	}
+
+	return stats, nil
+}
+
 func (h *AnalyticsHandler) getProjectStats(ctx context.Context) (ProjectStats, error) {
 	collection := config.GetCollection("projects")
 
@@ -0,0 +1,660 @@
+package handlers
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"ete-alumni-portal/config"
+	"ete-alumni-portal/middleware"
+	"ete-alumni-portal/models"
+	"ete-alumni-portal/utils"
+)
+
+type CohortHandler struct{}
+
+func NewCohortHandler() *CohortHandler {
+	return &CohortHandler{}
+}
+
+// cohortMemberFilter matches the listed members of a graduating batch
+func cohortMemberFilter(year int) bson.M {
+	return bson.M{
+		"graduation_year": year,
+		"is_verified":     true,
+		"is_active":       true,
+	}
+}
+
+func parseCohortYear(c *fiber.Ctx) (int, bool) {
+	year, err := strconv.Atoi(c.Params("year"))
+	if err != nil || year < 2000 || year > 2030 {
+		return 0, false
+	}
+	return year, true
+}
+
+func (h *CohortHandler) GetCohorts(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	cursor, err := config.GetCollection("users").Aggregate(ctx, bson.A{
+		bson.M{"$match": bson.M{
+			"graduation_year": bson.M{"$gt": 0},
+			"is_verified":     true,
+			"is_active":       true,
+		}},
+		bson.M{"$group": bson.M{"_id": "$graduation_year", "member_count": bson.M{"$sum": 1}}},
+		bson.M{"$sort": bson.M{"_id": -1}},
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to fetch cohorts",
+		})
+	}
+	defer cursor.Close(ctx)
+
+	cohorts := []models.Cohort{}
+	if err = cursor.All(ctx, &cohorts); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to decode cohorts",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"error": false,
+		"data":  cohorts,
+	})
+}
+
+func (h *CohortHandler) GetCohort(c *fiber.Ctx) error {
+	year, ok := parseCohortYear(c)
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid graduation year",
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Members without public profiles are counted but not broken down, and
+	// locations only come from members who share their contact details.
+	visible := bson.M{"privacy.profile_visibility": bson.M{"$nin": []models.Visibility{models.VisibilityPrivate, models.VisibilityConnections}}}
+	top := func(pipeline ...bson.M) bson.A {
+		stages := bson.A{bson.M{"$match": visible}}
+		for _, stage := range pipeline {
+			stages = append(stages, stage)
+		}
+		return append(stages,
+			bson.M{"$group": bson.M{"_id": "$value", "count": bson.M{"$sum": 1}}},
+			bson.M{"$sort": bson.D{{Key: "count", Value: -1}, {Key: "_id", Value: 1}}},
+			bson.M{"$limit": 10},
+		)
+	}
+
+	cursor, err := config.GetCollection("users").Aggregate(ctx, bson.A{
+		bson.M{"$match": cohortMemberFilter(year)},
+		bson.M{"$facet": bson.M{
+			"roles": bson.A{
+				bson.M{"$group": bson.M{"_id": "$role", "count": bson.M{"$sum": 1}}},
+				bson.M{"$sort": bson.M{"count": -1}},
+			},
+			"companies": top(
+				bson.M{"$match": bson.M{"company": bson.M{"$nin": bson.A{nil, ""}}}},
+				bson.M{"$project": bson.M{"value": "$company"}},
+			),
+			"locations": top(
+				bson.M{"$match": bson.M{
+					"location":                   bson.M{"$nin": bson.A{nil, ""}},
+					"privacy.contact_visibility": bson.M{"$nin": []models.Visibility{models.VisibilityPrivate, models.VisibilityConnections}},
+				}},
+				bson.M{"$project": bson.M{"value": "$location"}},
+			),
+			"skills": top(
+				bson.M{"$unwind": "$skills"},
+				bson.M{"$project": bson.M{"value": bson.M{"$toLower": bson.M{"$trim": bson.M{"input": "$skills"}}}}},
+				bson.M{"$match": bson.M{"value": bson.M{"$ne": ""}}},
+			),
+		}},
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to compute cohort stats",
+		})
+	}
+	defer cursor.Close(ctx)
+
+	var facets []struct {
+		Roles     []models.CohortCount `bson:"roles"`
+		Companies []models.CohortCount `bson:"companies"`
+		Locations []models.CohortCount `bson:"locations"`
+		Skills    []models.CohortCount `bson:"skills"`
+	}
+	if err = cursor.All(ctx, &facets); err != nil || len(facets) == 0 {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to decode cohort stats",
+		})
+	}
+
+	stats := models.CohortStats{
+		Year:       year,
+		RoleCounts: facets[0].Roles,
+		Companies:  facets[0].Companies,
+		Locations:  facets[0].Locations,
+		TopSkills:  facets[0].Skills,
+	}
+	for _, role := range stats.RoleCounts {
+		stats.MemberCount += role.Count
+	}
+
+	if stats.MemberCount == 0 {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "Cohort not found",
+		})
+	}
+
+	stats.Representatives, _ = cohortRepresentatives(ctx, middleware.GetUserID(c), year)
+
+	return c.JSON(fiber.Map{
+		"error": false,
+		"data":  stats,
+	})
+}
+
+func (h *CohortHandler) GetCohortMembers(c *fiber.Ctx) error {
+	viewerID := middleware.GetUserID(c)
+	year, ok := parseCohortYear(c)
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid graduation year",
+		})
+	}
+
+	// Parse query parameters
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+	search := c.Query("search")
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	filter := cohortMemberFilter(year)
+	if search != "" {
+		filter["$or"] = []bson.M{
+			{"name": bson.M{"$regex": search, "$options": "i"}},
+			{"company": bson.M{"$regex": search, "$options": "i"}},
+			{"skills": bson.M{"$regex": search, "$options": "i"}},
+		}
+	}
+
+	collection := config.GetCollection("users")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Get total count
+	total, err := collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to count members",
+		})
+	}
+
+	// Get members with pagination
+	skip := (page - 1) * limit
+	opts := options.Find().
+		SetSkip(int64(skip)).
+		SetLimit(int64(limit)).
+		SetSort(bson.M{"name": 1})
+
+	cursor, err := collection.Find(ctx, filter, opts)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to fetch members",
+		})
+	}
+	defer cursor.Close(ctx)
+
+	var users []models.User
+	if err = cursor.All(ctx, &users); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to decode members",
+		})
+	}
+
+	connected, _ := connectedUserIDs(ctx, viewerID)
+	members := make([]*models.UserResponse, len(users))
+	for i := range users {
+		members[i] = users[i].ToResponseFor(viewerID, connected[users[i].ID])
+	}
+
+	return c.JSON(fiber.Map{
+		"error": false,
+		"data": fiber.Map{
+			"members": members,
+			"pagination": fiber.Map{
+				"page":        page,
+				"limit":       limit,
+				"total":       total,
+				"total_pages": (total + int64(limit) - 1) / int64(limit),
+			},
+		},
+	})
+}
+
+func (h *CohortHandler) GetAnnouncements(c *fiber.Ctx) error {
+	userID := middleware.GetUserID(c)
+	year, ok := parseCohortYear(c)
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid graduation year",
+		})
+	}
+
+	// Parse query parameters
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if !canReadCohort(ctx, userID, middleware.GetUserRole(c), year) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error":   true,
+			"message": "Only members of this cohort can view its announcements",
+		})
+	}
+
+	collection := config.GetCollection("cohort_announcements")
+	filter := bson.M{"year": year, "is_active": true}
+
+	// Get total count
+	total, err := collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to count announcements",
+		})
+	}
+
+	// Get announcements with pagination
+	skip := (page - 1) * limit
+	opts := options.Find().
+		SetSkip(int64(skip)).
+		SetLimit(int64(limit)).
+		SetSort(bson.M{"created_at": -1})
+
+	cursor, err := collection.Find(ctx, filter, opts)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to fetch announcements",
+		})
+	}
+	defer cursor.Close(ctx)
+
+	var announcements []models.CohortAnnouncement
+	if err = cursor.All(ctx, &announcements); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to decode announcements",
+		})
+	}
+
+	// Populate author information
+	usersCollection := config.GetCollection("users")
+	for i := range announcements {
+		var author models.User
+		err := usersCollection.FindOne(ctx, bson.M{"_id": announcements[i].AuthorID}).Decode(&author)
+		if err == nil {
+			announcements[i].Author = author.ToResponse()
+		}
+	}
+
+	return c.JSON(fiber.Map{
+		"error": false,
+		"data": fiber.Map{
+			"announcements": announcements,
+			"pagination": fiber.Map{
+				"page":        page,
+				"limit":       limit,
+				"total":       total,
+				"total_pages": (total + int64(limit) - 1) / int64(limit),
+			},
+		},
+	})
+}
+
+func (h *CohortHandler) CreateAnnouncement(c *fiber.Ctx) error {
+	userID := middleware.GetUserID(c)
+	year, ok := parseCohortYear(c)
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid graduation year",
+		})
+	}
+
+	var req models.CreateCohortAnnouncementRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid request body",
+		})
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": err.Error(),
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if middleware.GetUserRole(c) != models.RoleAdmin && !isCohortRepresentative(ctx, userID, year) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error":   true,
+			"message": "Only cohort representatives can post announcements",
+		})
+	}
+
+	now := time.Now()
+	announcement := models.CohortAnnouncement{
+		ID:        primitive.NewObjectID(),
+		Year:      year,
+		AuthorID:  userID,
+		Title:     utils.SanitizeString(req.Title),
+		Content:   utils.SanitizeString(req.Content),
+		IsActive:  true,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	_, err := config.GetCollection("cohort_announcements").InsertOne(ctx, announcement)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to create announcement",
+		})
+	}
+
+	// Notify cohort members in background
+	go h.notifyCohort(announcement)
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"error":   false,
+		"message": "Announcement posted successfully",
+		"data":    announcement,
+	})
+}
+
+func (h *CohortHandler) DeleteAnnouncement(c *fiber.Ctx) error {
+	userID := middleware.GetUserID(c)
+	year, ok := parseCohortYear(c)
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid graduation year",
+		})
+	}
+
+	announcementID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid announcement ID",
+		})
+	}
+
+	filter := bson.M{"_id": announcementID, "year": year}
+	if middleware.GetUserRole(c) != models.RoleAdmin {
+		filter["author_id"] = userID
+	}
+
+	collection := config.GetCollection("cohort_announcements")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Soft delete by setting is_active to false
+	result, err := collection.UpdateOne(ctx, filter, bson.M{
+		"$set": bson.M{
+			"is_active":  false,
+			"updated_at": time.Now(),
+		},
+	})
+	if err != nil || result.MatchedCount == 0 {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "Announcement not found or access denied",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"error":   false,
+		"message": "Announcement deleted successfully",
+	})
+}
+
+func (h *CohortHandler) AssignRepresentative(c *fiber.Ctx) error {
+	adminID := middleware.GetUserID(c)
+	year, ok := parseCohortYear(c)
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid graduation year",
+		})
+	}
+
+	var req models.AssignRepresentativeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid request body",
+		})
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": err.Error(),
+		})
+	}
+
+	userID, err := primitive.ObjectIDFromHex(req.UserID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid user ID",
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Representatives must belong to the cohort
+	filter := cohortMemberFilter(year)
+	filter["_id"] = userID
+	count, err := config.GetCollection("users").CountDocuments(ctx, filter)
+	if err != nil || count == 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "User is not a member of this cohort",
+		})
+	}
+
+	result, err := config.GetCollection("cohort_representatives").UpdateOne(ctx,
+		bson.M{"year": year, "user_id": userID},
+		bson.M{"$setOnInsert": models.CohortRepresentative{
+			ID:         primitive.NewObjectID(),
+			Year:       year,
+			UserID:     userID,
+			AssignedBy: adminID,
+			CreatedAt:  time.Now(),
+		}},
+		options.Update().SetUpsert(true),
+	)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to assign representative",
+		})
+	}
+
+	if result.UpsertedCount > 0 {
+		createNotification(ctx, userID,
+			"Cohort Representative",
+			"You are now a representative for the class of "+strconv.Itoa(year),
+			models.NotificationCohortUpdate,
+			nil,
+			"cohort",
+		)
+	}
+
+	return c.JSON(fiber.Map{
+		"error":   false,
+		"message": "Representative assigned successfully",
+	})
+}
+
+func (h *CohortHandler) RemoveRepresentative(c *fiber.Ctx) error {
+	year, ok := parseCohortYear(c)
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid graduation year",
+		})
+	}
+
+	userID, err := primitive.ObjectIDFromHex(c.Params("userId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid user ID",
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	result, err := config.GetCollection("cohort_representatives").DeleteOne(ctx, bson.M{
+		"year":    year,
+		"user_id": userID,
+	})
+	if err != nil || result.DeletedCount == 0 {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "Representative not found",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"error":   false,
+		"message": "Representative removed successfully",
+	})
+}
+
+func (h *CohortHandler) notifyCohort(announcement models.CohortAnnouncement) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	filter := cohortMemberFilter(announcement.Year)
+	filter["_id"] = bson.M{"$ne": announcement.AuthorID}
+
+	cursor, err := config.GetCollection("users").Find(ctx, filter,
+		options.Find().SetProjection(bson.M{"_id": 1}),
+	)
+	if err != nil {
+		return
+	}
+	defer cursor.Close(ctx)
+
+	var members []models.User
+	cursor.All(ctx, &members)
+
+	for _, member := range members {
+		createNotification(ctx, member.ID,
+			"Class of "+strconv.Itoa(announcement.Year)+": "+announcement.Title,
+			"A new announcement was posted for your cohort",
+			models.NotificationCohortUpdate,
+			&announcement.ID,
+			"cohort_announcement",
+		)
+	}
+}
+
+func isCohortRepresentative(ctx context.Context, userID primitive.ObjectID, year int) bool {
+	count, err := config.GetCollection("cohort_representatives").CountDocuments(ctx, bson.M{
+		"year":    year,
+		"user_id": userID,
+	})
+	return err == nil && count > 0
+}
+
+// canReadCohort allows cohort members, faculty and admins
+func canReadCohort(ctx context.Context, userID primitive.ObjectID, role models.UserRole, year int) bool {
+	if role == models.RoleAdmin || role == models.RoleFaculty {
+		return true
+	}
+
+	filter := cohortMemberFilter(year)
+	filter["_id"] = userID
+	count, err := config.GetCollection("users").CountDocuments(ctx, filter)
+	return err == nil && count > 0
+}
+
+func cohortRepresentatives(ctx context.Context, viewerID primitive.ObjectID, year int) ([]*models.UserResponse, error) {
+	representatives := []*models.UserResponse{}
+
+	userIDs, err := config.GetCollection("cohort_representatives").Distinct(ctx, "user_id", bson.M{"year": year})
+	if err != nil || len(userIDs) == 0 {
+		return representatives, err
+	}
+
+	cursor, err := config.GetCollection("users").Find(ctx, bson.M{
+		"_id":       bson.M{"$in": userIDs},
+		"is_active": true,
+	})
+	if err != nil {
+		return representatives, err
+	}
+	defer cursor.Close(ctx)
+
+	var users []models.User
+	if err = cursor.All(ctx, &users); err != nil {
+		return representatives, err
+	}
+
+	for i := range users {
+		representatives = append(representatives, users[i].ToResponseFor(viewerID, areConnected(ctx, viewerID, users[i].ID)))
+	}
+	return representatives, nil
+}
@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+
+	"ete-alumni-portal/config"
+	"ete-alumni-portal/models"
+	"ete-alumni-portal/utils"
+)
+
+// profileCompleteness scores a user's profile and stores the score on the
+// user document so it can be aggregated for analytics.
+func profileCompleteness(ctx context.Context, user *models.User) models.ProfileCompleteness {
+	projectCount, _ := config.GetCollection("projects").CountDocuments(ctx, bson.M{
+		"is_active": true,
+		"$and":      []bson.M{projectMemberFilter(user.ID)},
+	})
+	resumeCount, _ := config.GetCollection("resumes").CountDocuments(ctx, bson.M{"user_id": user.ID})
+
+	completeness := user.Completeness(projectCount, resumeCount)
+	if completeness.Score != user.ProfileCompleteness {
+		config.GetCollection("users").UpdateOne(ctx, bson.M{"_id": user.ID}, bson.M{
+			"$set": bson.M{"profile_completeness": completeness.Score},
+		})
+		user.ProfileCompleteness = completeness.Score
+	}
+
+	return completeness
+}
+
+// SendProfileNudges periodically rescores profiles and reminds users with
+// incomplete profiles what is missing.
+func SendProfileNudges() {
+	ticker := time.NewTicker(24 * time.Hour)
+	defer ticker.Stop()
+
+	emailService := utils.NewEmailService()
+	for {
+		nudgeIncompleteProfiles(emailService)
+		<-ticker.C
+	}
+}
+
+func nudgeIncompleteProfiles(emailService *utils.EmailService) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	cursor, err := config.GetCollection("users").Find(ctx, bson.M{
+		"is_verified": true,
+		"is_active":   true,
+	})
+	if err != nil {
+		log.Printf("Failed to fetch users for profile nudges: %v", err)
+		return
+	}
+	defer cursor.Close(ctx)
+
+	nudgeBefore := time.Now().Add(-config.GetConfig().ProfileNudgeInterval)
+	nudged := 0
+	for cursor.Next(ctx) {
+		var user models.User
+		if err := cursor.Decode(&user); err != nil {
+			continue
+		}
+
+		completeness := profileCompleteness(ctx, &user)
+		if completeness.Score >= models.ProfileCompletenessThreshold {
+			continue
+		}
+		if user.CompletenessNudgedAt != nil && user.CompletenessNudgedAt.After(nudgeBefore) {
+			continue
+		}
+
+		createNotification(ctx, user.ID,
+			"Your profile is "+strconv.Itoa(completeness.Score)+"% complete",
+			"Add "+strings.Join(completeness.Missing, ", ")+" to help others find you",
+			models.NotificationProfileNudge,
+			nil,
+			"profile",
+		)
+
+		// Respect users who opted out of nudge emails
+		optedOut, _ := config.GetCollection("user_preferences").CountDocuments(ctx, bson.M{
+			"user_id":                            user.ID,
+			"email_notifications.profile_nudges": false,
+		})
+		if optedOut == 0 {
+			emailService.SendProfileNudge(user.Email, user.Name, completeness.Score, completeness.Missing)
+		}
+
+		config.GetCollection("users").UpdateOne(ctx, bson.M{"_id": user.ID}, bson.M{
+			"$set": bson.M{"completeness_nudged_at": time.Now()},
+		})
+		nudged++
+	}
+
+	if nudged > 0 {
+		log.Printf("Sent profile completeness nudges to %d users", nudged)
+	}
+}
@@ -0,0 +1,809 @@
+package handlers
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"ete-alumni-portal/config"
+	"ete-alumni-portal/middleware"
+	"ete-alumni-portal/models"
+	"ete-alumni-portal/utils"
+)
+
+type ConnectionHandler struct{}
+
+func NewConnectionHandler() *ConnectionHandler {
+	return &ConnectionHandler{}
+}
+
+func (h *ConnectionHandler) SendConnectionRequest(c *fiber.Ctx) error {
+	userID := middleware.GetUserID(c)
+	targetIDStr := c.Params("id")
+	targetID, err := primitive.ObjectIDFromHex(targetIDStr)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid user ID",
+		})
+	}
+
+	if targetID == userID {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "You cannot connect with yourself",
+		})
+	}
+
+	var req models.ConnectionRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   true,
+				"message": "Invalid request body",
+			})
+		}
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": err.Error(),
+		})
+	}
+
+	usersCollection := config.GetCollection("users")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var target models.User
+	err = usersCollection.FindOne(ctx, bson.M{
+		"_id":         targetID,
+		"is_verified": true,
+		"is_active":   true,
+	}).Decode(&target)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "User not found",
+		})
+	}
+
+	var requester models.User
+	usersCollection.FindOne(ctx, bson.M{"_id": userID}).Decode(&requester)
+
+	collection := config.GetCollection("connections")
+	now := time.Now()
+
+	// Check for an existing connection in either direction
+	var existing models.Connection
+	err = collection.FindOne(ctx, connectionPairFilter(userID, targetID)).Decode(&existing)
+	if err == nil {
+		switch {
+		case existing.Status == models.ConnectionAccepted:
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error":   true,
+				"message": "You are already connected with this user",
+			})
+		case existing.Status == models.ConnectionPending && existing.RequesterID == userID:
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error":   true,
+				"message": "Connection request already sent",
+			})
+		case existing.Status == models.ConnectionPending:
+			// The other user already asked us, so sending a request back accepts it
+			return h.respond(c, ctx, existing, userID, models.ConnectionAccepted)
+		}
+
+		// A previously declined request can be sent again
+		_, err = collection.UpdateOne(ctx, bson.M{"_id": existing.ID}, bson.M{
+			"$set": bson.M{
+				"requester_id": userID,
+				"addressee_id": targetID,
+				"status":       models.ConnectionPending,
+				"message":      utils.SanitizeString(req.Message),
+				"updated_at":   now,
+			},
+			"$unset": bson.M{"responded_at": ""},
+		})
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error":   true,
+				"message": "Failed to send connection request",
+			})
+		}
+
+		createNotification(ctx, targetID,
+			"New Connection Request",
+			requester.Name+" wants to connect with you",
+			models.NotificationConnectionReq,
+			&existing.ID,
+			"connection",
+		)
+
+		return c.JSON(fiber.Map{
+			"error":   false,
+			"message": "Connection request sent successfully",
+		})
+	}
+
+	connection := models.Connection{
+		ID:          primitive.NewObjectID(),
+		RequesterID: userID,
+		AddresseeID: targetID,
+		Status:      models.ConnectionPending,
+		Message:     utils.SanitizeString(req.Message),
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	_, err = collection.InsertOne(ctx, connection)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to send connection request",
+		})
+	}
+
+	createNotification(ctx, targetID,
+		"New Connection Request",
+		requester.Name+" wants to connect with you",
+		models.NotificationConnectionReq,
+		&connection.ID,
+		"connection",
+	)
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"error":   false,
+		"message": "Connection request sent successfully",
+		"data":    connection,
+	})
+}
+
+func (h *ConnectionHandler) AcceptConnection(c *fiber.Ctx) error {
+	return h.respondToRequest(c, models.ConnectionAccepted)
+}
+
+func (h *ConnectionHandler) DeclineConnection(c *fiber.Ctx) error {
+	return h.respondToRequest(c, models.ConnectionDeclined)
+}
+
+func (h *ConnectionHandler) respondToRequest(c *fiber.Ctx, status models.ConnectionStatus) error {
+	userID := middleware.GetUserID(c)
+	requestIDStr := c.Params("id")
+	requestID, err := primitive.ObjectIDFromHex(requestIDStr)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid request ID",
+		})
+	}
+
+	collection := config.GetCollection("connections")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Only the addressee can respond to a pending request
+	var connection models.Connection
+	err = collection.FindOne(ctx, bson.M{
+		"_id":          requestID,
+		"addressee_id": userID,
+		"status":       models.ConnectionPending,
+	}).Decode(&connection)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "Connection request not found or access denied",
+		})
+	}
+
+	return h.respond(c, ctx, connection, userID, status)
+}
+
+func (h *ConnectionHandler) respond(c *fiber.Ctx, ctx context.Context, connection models.Connection, userID primitive.ObjectID, status models.ConnectionStatus) error {
+	collection := config.GetCollection("connections")
+	now := time.Now()
+
+	_, err := collection.UpdateOne(ctx, bson.M{"_id": connection.ID}, bson.M{
+		"$set": bson.M{
+			"status":       status,
+			"responded_at": now,
+			"updated_at":   now,
+		},
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to update connection request",
+		})
+	}
+
+	if status == models.ConnectionAccepted {
+		var user models.User
+		config.GetCollection("users").FindOne(ctx, bson.M{"_id": userID}).Decode(&user)
+
+		createNotification(ctx, connection.RequesterID,
+			"Connection Accepted",
+			user.Name+" accepted your connection request",
+			models.NotificationConnectionAccept,
+			&connection.ID,
+			"connection",
+		)
+
+		return c.JSON(fiber.Map{
+			"error":   false,
+			"message": "Connection request accepted",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"error":   false,
+		"message": "Connection request declined",
+	})
+}
+
+func (h *ConnectionHandler) RemoveConnection(c *fiber.Ctx) error {
+	userID := middleware.GetUserID(c)
+	targetIDStr := c.Params("id")
+	targetID, err := primitive.ObjectIDFromHex(targetIDStr)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid user ID",
+		})
+	}
+
+	collection := config.GetCollection("connections")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Removes an accepted connection or withdraws a request we sent
+	result, err := collection.DeleteOne(ctx, bson.M{
+		"$or": []bson.M{
+			{
+				"requester_id": userID,
+				"addressee_id": targetID,
+				"status":       bson.M{"$in": []models.ConnectionStatus{models.ConnectionPending, models.ConnectionAccepted}},
+			},
+			{
+				"requester_id": targetID,
+				"addressee_id": userID,
+				"status":       models.ConnectionAccepted,
+			},
+		},
+	})
+	if err != nil || result.DeletedCount == 0 {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "Connection not found",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"error":   false,
+		"message": "Connection removed successfully",
+	})
+}
+
+func (h *ConnectionHandler) GetConnections(c *fiber.Ctx) error {
+	userID := middleware.GetUserID(c)
+
+	// Parse query parameters
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	filter := bson.M{
+		"status": models.ConnectionAccepted,
+		"$or": []bson.M{
+			{"requester_id": userID},
+			{"addressee_id": userID},
+		},
+	}
+
+	return h.listConnections(c, userID, filter, bson.M{"responded_at": -1}, page, limit)
+}
+
+func (h *ConnectionHandler) GetConnectionRequests(c *fiber.Ctx) error {
+	userID := middleware.GetUserID(c)
+
+	// Parse query parameters
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+	direction := c.Query("direction", "incoming")
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	filter := bson.M{"status": models.ConnectionPending}
+	if direction == "outgoing" {
+		filter["requester_id"] = userID
+	} else {
+		filter["addressee_id"] = userID
+	}
+
+	return h.listConnections(c, userID, filter, bson.M{"created_at": -1}, page, limit)
+}
+
+func (h *ConnectionHandler) listConnections(c *fiber.Ctx, userID primitive.ObjectID, filter bson.M, sort bson.M, page, limit int) error {
+	collection := config.GetCollection("connections")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Get total count
+	total, err := collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to count connections",
+		})
+	}
+
+	// Get connections with pagination
+	skip := (page - 1) * limit
+	opts := options.Find().
+		SetSkip(int64(skip)).
+		SetLimit(int64(limit)).
+		SetSort(sort)
+
+	cursor, err := collection.Find(ctx, filter, opts)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to fetch connections",
+		})
+	}
+	defer cursor.Close(ctx)
+
+	var connections []models.Connection
+	if err = cursor.All(ctx, &connections); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to decode connections",
+		})
+	}
+
+	// Populate the other participant
+	connected, _ := connectedUserIDs(ctx, userID)
+	usersCollection := config.GetCollection("users")
+	for i := range connections {
+		otherID := connections[i].RequesterID
+		if otherID == userID {
+			otherID = connections[i].AddresseeID
+		}
+
+		var user models.User
+		err := usersCollection.FindOne(ctx, bson.M{"_id": otherID}).Decode(&user)
+		if err == nil {
+			connections[i].User = user.ToResponseFor(userID, connected[otherID])
+		}
+	}
+
+	return c.JSON(fiber.Map{
+		"error": false,
+		"data": fiber.Map{
+			"connections": connections,
+			"pagination": fiber.Map{
+				"page":        page,
+				"limit":       limit,
+				"total":       total,
+				"total_pages": (total + int64(limit) - 1) / int64(limit),
+			},
+		},
+	})
+}
+
+func (h *ConnectionHandler) GetRelationship(c *fiber.Ctx) error {
+	userID := middleware.GetUserID(c)
+	targetIDStr := c.Params("id")
+	targetID, err := primitive.ObjectIDFromHex(targetIDStr)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid user ID",
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	return c.JSON(fiber.Map{
+		"error": false,
+		"data":  getRelationship(ctx, userID, targetID),
+	})
+}
+
+func (h *ConnectionHandler) GetMutualConnections(c *fiber.Ctx) error {
+	userID := middleware.GetUserID(c)
+	targetIDStr := c.Params("id")
+	targetID, err := primitive.ObjectIDFromHex(targetIDStr)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid user ID",
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	mutualIDs, err := mutualConnectionIDs(ctx, userID, targetID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to fetch mutual connections",
+		})
+	}
+
+	users := []*models.UserResponse{}
+	if len(mutualIDs) > 0 {
+		usersCollection := config.GetCollection("users")
+		cursor, err := usersCollection.Find(ctx, bson.M{
+			"_id":       bson.M{"$in": mutualIDs},
+			"is_active": true,
+		}, options.Find().SetSort(bson.M{"name": 1}).SetLimit(100))
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error":   true,
+				"message": "Failed to fetch mutual connections",
+			})
+		}
+		defer cursor.Close(ctx)
+
+		var mutual []models.User
+		if err = cursor.All(ctx, &mutual); err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error":   true,
+				"message": "Failed to decode users",
+			})
+		}
+
+		// Mutual connections are by definition connected to the viewer
+		for i := range mutual {
+			users = append(users, mutual[i].ToResponseFor(userID, true))
+		}
+	}
+
+	return c.JSON(fiber.Map{
+		"error": false,
+		"data": fiber.Map{
+			"users": users,
+			"total": len(mutualIDs),
+		},
+	})
+}
+
+func (h *ConnectionHandler) FollowUser(c *fiber.Ctx) error {
+	userID := middleware.GetUserID(c)
+	targetIDStr := c.Params("id")
+	targetID, err := primitive.ObjectIDFromHex(targetIDStr)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid user ID",
+		})
+	}
+
+	if targetID == userID {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "You cannot follow yourself",
+		})
+	}
+
+	usersCollection := config.GetCollection("users")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	count, err := usersCollection.CountDocuments(ctx, bson.M{
+		"_id":         targetID,
+		"is_verified": true,
+		"is_active":   true,
+	})
+	if err != nil || count == 0 {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "User not found",
+		})
+	}
+
+	// Check if already following
+	followsCollection := config.GetCollection("follows")
+	var existingFollow models.Follow
+	err = followsCollection.FindOne(ctx, bson.M{
+		"follower_id": userID,
+		"followee_id": targetID,
+	}).Decode(&existingFollow)
+	if err == nil {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error":   true,
+			"message": "Already following this user",
+		})
+	}
+
+	follow := models.Follow{
+		ID:         primitive.NewObjectID(),
+		FollowerID: userID,
+		FolloweeID: targetID,
+		CreatedAt:  time.Now(),
+	}
+
+	_, err = followsCollection.InsertOne(ctx, follow)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to follow user",
+		})
+	}
+
+	var follower models.User
+	usersCollection.FindOne(ctx, bson.M{"_id": userID}).Decode(&follower)
+
+	createNotification(ctx, targetID,
+		"New Follower",
+		follower.Name+" started following you",
+		models.NotificationNewFollower,
+		&userID,
+		"user",
+	)
+
+	return c.JSON(fiber.Map{
+		"error":   false,
+		"message": "User followed successfully",
+	})
+}
+
+func (h *ConnectionHandler) UnfollowUser(c *fiber.Ctx) error {
+	userID := middleware.GetUserID(c)
+	targetIDStr := c.Params("id")
+	targetID, err := primitive.ObjectIDFromHex(targetIDStr)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid user ID",
+		})
+	}
+
+	followsCollection := config.GetCollection("follows")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	result, err := followsCollection.DeleteOne(ctx, bson.M{
+		"follower_id": userID,
+		"followee_id": targetID,
+	})
+	if err != nil || result.DeletedCount == 0 {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "Follow not found",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"error":   false,
+		"message": "User unfollowed successfully",
+	})
+}
+
+func (h *ConnectionHandler) GetFollowers(c *fiber.Ctx) error {
+	return h.listFollows(c, "followee_id", "follower_id")
+}
+
+func (h *ConnectionHandler) GetFollowing(c *fiber.Ctx) error {
+	return h.listFollows(c, "follower_id", "followee_id")
+}
+
+// listFollows lists follows where matchField is the requested user and
+// returns the user found in userField for each of them.
+func (h *ConnectionHandler) listFollows(c *fiber.Ctx, matchField, userField string) error {
+	viewerID := middleware.GetUserID(c)
+	userIDStr := c.Params("id")
+	userID, err := primitive.ObjectIDFromHex(userIDStr)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid user ID",
+		})
+	}
+
+	// Parse query parameters
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	filter := bson.M{matchField: userID}
+
+	collection := config.GetCollection("follows")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Get total count
+	total, err := collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to count follows",
+		})
+	}
+
+	// Get follows with pagination
+	skip := (page - 1) * limit
+	opts := options.Find().
+		SetSkip(int64(skip)).
+		SetLimit(int64(limit)).
+		SetSort(bson.M{"created_at": -1})
+
+	cursor, err := collection.Find(ctx, filter, opts)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to fetch follows",
+		})
+	}
+	defer cursor.Close(ctx)
+
+	var follows []models.Follow
+	if err = cursor.All(ctx, &follows); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to decode follows",
+		})
+	}
+
+	// Populate user information
+	connected, _ := connectedUserIDs(ctx, viewerID)
+	usersCollection := config.GetCollection("users")
+	for i := range follows {
+		otherID := follows[i].FollowerID
+		if userField == "followee_id" {
+			otherID = follows[i].FolloweeID
+		}
+
+		var user models.User
+		err := usersCollection.FindOne(ctx, bson.M{"_id": otherID}).Decode(&user)
+		if err == nil {
+			follows[i].User = user.ToResponseFor(viewerID, connected[otherID])
+		}
+	}
+
+	return c.JSON(fiber.Map{
+		"error": false,
+		"data": fiber.Map{
+			"users": follows,
+			"pagination": fiber.Map{
+				"page":        page,
+				"limit":       limit,
+				"total":       total,
+				"total_pages": (total + int64(limit) - 1) / int64(limit),
+			},
+		},
+	})
+}
+
+func connectionPairFilter(a, b primitive.ObjectID) bson.M {
+	return bson.M{
+		"$or": []bson.M{
+			{"requester_id": a, "addressee_id": b},
+			{"requester_id": b, "addressee_id": a},
+		},
+	}
+}
+
+// areConnected reports whether two users have an accepted connection
+func areConnected(ctx context.Context, a, b primitive.ObjectID) bool {
+	filter := connectionPairFilter(a, b)
+	filter["status"] = models.ConnectionAccepted
+
+	count, err := config.GetCollection("connections").CountDocuments(ctx, filter)
+	return err == nil && count > 0
+}
+
+// connectedUserIDs returns the set of users that have an accepted connection
+// with userID. It is used to apply privacy rules to lists of users.
+func connectedUserIDs(ctx context.Context, userID primitive.ObjectID) (map[primitive.ObjectID]bool, error) {
+	connected := make(map[primitive.ObjectID]bool)
+
+	cursor, err := config.GetCollection("connections").Find(ctx, bson.M{
+		"status": models.ConnectionAccepted,
+		"$or": []bson.M{
+			{"requester_id": userID},
+			{"addressee_id": userID},
+		},
+	})
+	if err != nil {
+		return connected, err
+	}
+	defer cursor.Close(ctx)
+
+	var connections []models.Connection
+	if err = cursor.All(ctx, &connections); err != nil {
+		return connected, err
+	}
+
+	for _, connection := range connections {
+		if connection.RequesterID == userID {
+			connected[connection.AddresseeID] = true
+		} else {
+			connected[connection.RequesterID] = true
+		}
+	}
+
+	return connected, nil
+}
+
+func mutualConnectionIDs(ctx context.Context, a, b primitive.ObjectID) ([]primitive.ObjectID, error) {
+	connectedA, err := connectedUserIDs(ctx, a)
+	if err != nil {
+		return nil, err
+	}
+	connectedB, err := connectedUserIDs(ctx, b)
+	if err != nil {
+		return nil, err
+	}
+
+	var mutual []primitive.ObjectID
+	for id := range connectedA {
+		if connectedB[id] {
+			mutual = append(mutual, id)
+		}
+	}
+	return mutual, nil
+}
+
+// getRelationship describes how viewerID relates to userID
+func getRelationship(ctx context.Context, viewerID, userID primitive.ObjectID) models.Relationship {
+	relationship := models.Relationship{Status: models.RelationshipNone}
+	if viewerID == userID {
+		relationship.Status = models.RelationshipSelf
+		return relationship
+	}
+
+	var connection models.Connection
+	err := config.GetCollection("connections").FindOne(ctx, connectionPairFilter(viewerID, userID)).Decode(&connection)
+	if err == nil {
+		switch {
+		case connection.Status == models.ConnectionAccepted:
+			relationship.Status = models.RelationshipConnected
+			relationship.ConnectionID = &connection.ID
+		case connection.Status == models.ConnectionPending && connection.RequesterID == viewerID:
+			relationship.Status = models.RelationshipPendingSent
+			relationship.ConnectionID = &connection.ID
+		case connection.Status == models.ConnectionPending:
+			relationship.Status = models.RelationshipPendingReceived
+			relationship.ConnectionID = &connection.ID
+		}
+	}
+
+	followsCollection := config.GetCollection("follows")
+	following, _ := followsCollection.CountDocuments(ctx, bson.M{"follower_id": viewerID, "followee_id": userID})
+	followedBy, _ := followsCollection.CountDocuments(ctx, bson.M{"follower_id": userID, "followee_id": viewerID})
+	relationship.IsFollowing = following > 0
+	relationship.IsFollowedBy = followedBy > 0
+
+	if mutual, err := mutualConnectionIDs(ctx, viewerID, userID); err == nil {
+		relationship.MutualCount = len(mutual)
+	}
+
+	return relationship
+}
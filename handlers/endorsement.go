@@ -0,0 +1,321 @@
+package handlers
+
+import (
+	"context"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"ete-alumni-portal/config"
+	"ete-alumni-portal/middleware"
+	"ete-alumni-portal/models"
+	"ete-alumni-portal/utils"
+)
+
+type EndorsementHandler struct{}
+
+func NewEndorsementHandler() *EndorsementHandler {
+	return &EndorsementHandler{}
+}
+
+func (h *EndorsementHandler) EndorseSkill(c *fiber.Ctx) error {
+	endorserID := middleware.GetUserID(c)
+	userIDStr := c.Params("id")
+	userID, err := primitive.ObjectIDFromHex(userIDStr)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid user ID",
+		})
+	}
+
+	if userID == endorserID {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "You cannot endorse your own skills",
+		})
+	}
+
+	var req models.EndorseSkillRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid request body",
+		})
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": err.Error(),
+		})
+	}
+
+	usersCollection := config.GetCollection("users")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Only verified, active users can endorse
+	var endorser models.User
+	err = usersCollection.FindOne(ctx, bson.M{
+		"_id":         endorserID,
+		"is_verified": true,
+		"is_active":   true,
+	}).Decode(&endorser)
+	if err != nil {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error":   true,
+			"message": "Only verified users can endorse skills",
+		})
+	}
+
+	var user models.User
+	err = usersCollection.FindOne(ctx, bson.M{
+		"_id":         userID,
+		"is_verified": true,
+		"is_active":   true,
+	}).Decode(&user)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "User not found",
+		})
+	}
+
+	// The skill must be listed on the recipient's profile
+	key := skillKey(req.Skill)
+	skill := ""
+	for _, s := range user.Skills {
+		if skillKey(s) == key {
+			skill = s
+			break
+		}
+	}
+	if skill == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Skill is not listed on this profile",
+		})
+	}
+
+	// Check if already endorsed
+	endorsementsCollection := config.GetCollection("skill_endorsements")
+	var existingEndorsement models.SkillEndorsement
+	err = endorsementsCollection.FindOne(ctx, bson.M{
+		"user_id":     userID,
+		"endorser_id": endorserID,
+		"skill_key":   key,
+	}).Decode(&existingEndorsement)
+	if err == nil {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error":   true,
+			"message": "You have already endorsed this skill",
+		})
+	}
+
+	endorsement := models.SkillEndorsement{
+		ID:         primitive.NewObjectID(),
+		UserID:     userID,
+		EndorserID: endorserID,
+		Skill:      skill,
+		SkillKey:   key,
+		CreatedAt:  time.Now(),
+	}
+
+	_, err = endorsementsCollection.InsertOne(ctx, endorsement)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to endorse skill",
+		})
+	}
+
+	// Increment endorsements count used for directory ranking
+	_, err = usersCollection.UpdateOne(ctx, bson.M{"_id": userID}, bson.M{
+		"$inc": bson.M{"endorsements_count": 1},
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to update endorsements count",
+		})
+	}
+
+	createNotification(ctx, userID,
+		"New Skill Endorsement",
+		endorser.Name+" endorsed you for "+skill,
+		models.NotificationSkillEndorsed,
+		&endorsement.ID,
+		"skill_endorsement",
+	)
+
+	endorsement.Endorser = endorser.ToResponse()
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"error":   false,
+		"message": "Skill endorsed successfully",
+		"data":    endorsement,
+	})
+}
+
+func (h *EndorsementHandler) RemoveEndorsement(c *fiber.Ctx) error {
+	endorserID := middleware.GetUserID(c)
+	userIDStr := c.Params("id")
+	userID, err := primitive.ObjectIDFromHex(userIDStr)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid user ID",
+		})
+	}
+
+	// Path params aren't unescaped, so "Machine%20Learning" arrives encoded
+	skill, err := url.PathUnescape(c.Params("skill"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid skill",
+		})
+	}
+
+	endorsementsCollection := config.GetCollection("skill_endorsements")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Remove endorsement
+	result, err := endorsementsCollection.DeleteOne(ctx, bson.M{
+		"user_id":     userID,
+		"endorser_id": endorserID,
+		"skill_key":   skillKey(skill),
+	})
+	if err != nil || result.DeletedCount == 0 {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "Endorsement not found",
+		})
+	}
+
+	// Decrement endorsements count
+	usersCollection := config.GetCollection("users")
+	_, err = usersCollection.UpdateOne(ctx, bson.M{"_id": userID}, bson.M{
+		"$inc": bson.M{"endorsements_count": -1},
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to update endorsements count",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"error":   false,
+		"message": "Endorsement removed successfully",
+	})
+}
+
+func (h *EndorsementHandler) GetEndorsements(c *fiber.Ctx) error {
+	userIDStr := c.Params("id")
+	userID, err := primitive.ObjectIDFromHex(userIDStr)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid user ID",
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	summaries, err := getSkillEndorsements(ctx, userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to fetch endorsements",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"error": false,
+		"data":  summaries,
+	})
+}
+
+// getSkillEndorsements returns the endorsements a user received, grouped per
+// skill and ordered by endorsement count.
+func getSkillEndorsements(ctx context.Context, userID primitive.ObjectID) ([]models.SkillEndorsementSummary, error) {
+	endorsementsCollection := config.GetCollection("skill_endorsements")
+	cursor, err := endorsementsCollection.Find(ctx, bson.M{"user_id": userID},
+		options.Find().SetSort(bson.M{"created_at": -1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var endorsements []models.SkillEndorsement
+	if err = cursor.All(ctx, &endorsements); err != nil {
+		return nil, err
+	}
+
+	summaries := []models.SkillEndorsementSummary{}
+	if len(endorsements) == 0 {
+		return summaries, nil
+	}
+
+	// Load all endorsers in one query
+	endorserIDs := make([]primitive.ObjectID, 0, len(endorsements))
+	for _, e := range endorsements {
+		endorserIDs = append(endorserIDs, e.EndorserID)
+	}
+
+	usersCollection := config.GetCollection("users")
+	userCursor, err := usersCollection.Find(ctx, bson.M{"_id": bson.M{"$in": endorserIDs}})
+	if err != nil {
+		return nil, err
+	}
+	defer userCursor.Close(ctx)
+
+	var endorsers []models.User
+	if err = userCursor.All(ctx, &endorsers); err != nil {
+		return nil, err
+	}
+
+	endorsersByID := make(map[primitive.ObjectID]*models.UserResponse, len(endorsers))
+	for i := range endorsers {
+		endorsersByID[endorsers[i].ID] = endorsers[i].ToResponse()
+	}
+
+	index := make(map[string]int)
+	for _, e := range endorsements {
+		i, ok := index[e.SkillKey]
+		if !ok {
+			i = len(summaries)
+			index[e.SkillKey] = i
+			summaries = append(summaries, models.SkillEndorsementSummary{
+				Skill:     e.Skill,
+				Endorsers: []*models.UserResponse{},
+			})
+		}
+
+		summaries[i].Count++
+		if endorser, ok := endorsersByID[e.EndorserID]; ok {
+			summaries[i].Endorsers = append(summaries[i].Endorsers, endorser)
+		}
+	}
+
+	sort.SliceStable(summaries, func(i, j int) bool {
+		return summaries[i].Count > summaries[j].Count
+	})
+
+	return summaries, nil
+}
+
+// skillKey normalizes a skill name so endorsements match regardless of case
+func skillKey(skill string) string {
+	return strings.ToLower(strings.TrimSpace(skill))
+}
@@ -0,0 +1,361 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"ete-alumni-portal/config"
+	"ete-alumni-portal/middleware"
+	"ete-alumni-portal/models"
+	"ete-alumni-portal/utils"
+)
+
+const (
+	exportInstitution = "Dr. Ambedkar Institute of Technology, Bengaluru"
+	exportDepartment  = "Electronics and Telecommunication Engineering"
+)
+
+var exportFilenameUnsafe = regexp.MustCompile(`[^a-zA-Z0-9]+`)
+
+type ExportHandler struct{}
+
+func NewExportHandler() *ExportHandler {
+	return &ExportHandler{}
+}
+
+// exportProfile is the privacy-filtered data every export format is rendered from
+type exportProfile struct {
+	user     *models.UserResponse
+	bio      string
+	projects []models.Project
+}
+
+func (h *ExportHandler) ExportJSONResume(c *fiber.Ctx) error {
+	profile, err := h.loadProfile(c)
+	if err != nil || profile == nil {
+		return err
+	}
+
+	resume := buildJSONResume(profile)
+
+	c.Set(fiber.HeaderContentDisposition, `attachment; filename="`+exportFilename(profile.user.Name)+`.json"`)
+	return c.JSON(resume)
+}
+
+func (h *ExportHandler) ExportVCard(c *fiber.Ctx) error {
+	profile, err := h.loadProfile(c)
+	if err != nil || profile == nil {
+		return err
+	}
+
+	c.Set(fiber.HeaderContentType, "text/vcard; charset=utf-8")
+	c.Set(fiber.HeaderContentDisposition, `attachment; filename="`+exportFilename(profile.user.Name)+`.vcf"`)
+	return c.SendString(buildVCard(profile))
+}
+
+func (h *ExportHandler) ExportPDF(c *fiber.Ctx) error {
+	profile, err := h.loadProfile(c)
+	if err != nil || profile == nil {
+		return err
+	}
+
+	c.Set(fiber.HeaderContentType, "application/pdf")
+	c.Set(fiber.HeaderContentDisposition, `attachment; filename="`+exportFilename(profile.user.Name)+`-cv.pdf"`)
+	return c.Send(buildResumePDF(profile))
+}
+
+// loadProfile fetches the user named by :id as the current viewer is allowed
+// to see them. A nil profile means an error response has been written.
+func (h *ExportHandler) loadProfile(c *fiber.Ctx) (*exportProfile, error) {
+	viewerID := middleware.GetUserID(c)
+	userID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return nil, c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid user ID",
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var user models.User
+	err = config.GetCollection("users").FindOne(ctx, bson.M{
+		"_id":         userID,
+		"is_verified": true,
+		"is_active":   true,
+	}).Decode(&user)
+	if err != nil {
+		return nil, c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "User not found",
+		})
+	}
+
+	response := user.ToResponseFor(viewerID, areConnected(ctx, viewerID, user.ID))
+	if response.ProfileRestricted {
+		return nil, c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error":   true,
+			"message": "This profile is private",
+		})
+	}
+
+	cursor, err := config.GetCollection("projects").Find(ctx, bson.M{
+		"is_active": true,
+		"status":    approvedProjectStatus(),
+		"$and":      []bson.M{projectMemberFilter(user.ID)},
+	}, options.Find().SetSort(bson.M{"created_at": -1}))
+	if err != nil {
+		return nil, c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to fetch projects",
+		})
+	}
+	defer cursor.Close(ctx)
+
+	var projects []models.Project
+	if err = cursor.All(ctx, &projects); err != nil {
+		return nil, c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to decode projects",
+		})
+	}
+
+	return &exportProfile{
+		user:     response,
+		bio:      user.Bio,
+		projects: projects,
+	}, nil
+}
+
+func buildJSONResume(profile *exportProfile) models.JSONResume {
+	user := profile.user
+
+	resume := models.JSONResume{
+		Basics: models.JSONResumeBasics{
+			Name:    user.Name,
+			Label:   user.Position,
+			Image:   user.AvatarURL,
+			Email:   user.Email,
+			Summary: profile.bio,
+		},
+		Meta: map[string]interface{}{
+			"canonical":    config.GetConfig().FrontendURL + "/users/" + user.ID.Hex(),
+			"lastModified": time.Now().Format(time.RFC3339),
+		},
+	}
+
+	if user.Location != "" {
+		resume.Basics.Location = &models.JSONResumeLocation{City: user.Location}
+	}
+	if user.GitHubURL != "" {
+		resume.Basics.Profiles = append(resume.Basics.Profiles, models.JSONResumeProfile{Network: "GitHub", URL: user.GitHubURL})
+	}
+	if user.LinkedInURL != "" {
+		resume.Basics.Profiles = append(resume.Basics.Profiles, models.JSONResumeProfile{Network: "LinkedIn", URL: user.LinkedInURL})
+	}
+
+	if user.Company != "" {
+		resume.Work = append(resume.Work, models.JSONResumeWork{
+			Name:     user.Company,
+			Position: user.Position,
+			Summary:  user.Experience,
+		})
+	}
+
+	education := models.JSONResumeEducation{
+		Institution: exportInstitution,
+		Area:        exportDepartment,
+		StudyType:   "Bachelor of Engineering",
+	}
+	if user.GraduationYear != 0 {
+		education.EndDate = strconv.Itoa(user.GraduationYear)
+	}
+	if user.CGPA != 0 {
+		education.Score = strconv.FormatFloat(user.CGPA, 'f', 2, 64) + " CGPA"
+	}
+	resume.Education = append(resume.Education, education)
+
+	for _, skill := range user.Skills {
+		resume.Skills = append(resume.Skills, models.JSONResumeSkill{Name: skill})
+	}
+
+	for _, project := range profile.projects {
+		url := project.DemoURL
+		if url == "" {
+			url = project.GitHubURL
+		}
+		resume.Projects = append(resume.Projects, models.JSONResumeProject{
+			Name:        project.Title,
+			Description: project.Description,
+			URL:         url,
+			Keywords:    project.Technologies,
+			StartDate:   project.CreatedAt.Format("2006-01-02"),
+			Type:        string(project.ProjectType) + " project",
+		})
+	}
+
+	return resume
+}
+
+// buildVCard renders the profile as a vCard 4.0 (RFC 6350)
+func buildVCard(profile *exportProfile) string {
+	user := profile.user
+
+	var lines []string
+	add := func(line string) {
+		lines = append(lines, foldVCardLine(line))
+	}
+
+	add("BEGIN:VCARD")
+	add("VERSION:4.0")
+	add("FN:" + escapeVCard(user.Name))
+
+	// Best effort split into family and given names
+	parts := strings.Fields(user.Name)
+	family, given := "", user.Name
+	if len(parts) > 1 {
+		family = parts[len(parts)-1]
+		given = strings.Join(parts[:len(parts)-1], " ")
+	}
+	add("N:" + escapeVCard(family) + ";" + escapeVCard(given) + ";;;")
+
+	if user.Email != "" {
+		add("EMAIL;TYPE=work:" + escapeVCard(user.Email))
+	}
+	if user.Company != "" {
+		add("ORG:" + escapeVCard(user.Company))
+	}
+	if user.Position != "" {
+		add("TITLE:" + escapeVCard(user.Position))
+	}
+	if user.Location != "" {
+		add(`ADR;LABEL="` + strings.ReplaceAll(user.Location, `"`, "'") + `":;;;` + escapeVCard(user.Location) + ";;;")
+	}
+	if user.AvatarURL != "" {
+		add("PHOTO:" + user.AvatarURL)
+	}
+	if user.GitHubURL != "" {
+		add("URL;TYPE=github:" + user.GitHubURL)
+	}
+	if user.LinkedInURL != "" {
+		add("URL;TYPE=linkedin:" + user.LinkedInURL)
+	}
+	if len(user.Skills) > 0 {
+		skills := make([]string, len(user.Skills))
+		for i, skill := range user.Skills {
+			skills[i] = escapeVCard(skill)
+		}
+		add("CATEGORIES:" + strings.Join(skills, ","))
+	}
+	if profile.bio != "" {
+		add("NOTE:" + escapeVCard(profile.bio))
+	}
+	add("SOURCE:" + config.GetConfig().FrontendURL + "/users/" + user.ID.Hex())
+	add("REV:" + time.Now().UTC().Format("20060102T150405Z"))
+	add("END:VCARD")
+
+	return strings.Join(lines, "\r\n") + "\r\n"
+}
+
+func escapeVCard(value string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, ",", `\,`, ";", `\;`, "\r\n", `\n`, "\n", `\n`)
+	return replacer.Replace(value)
+}
+
+// foldVCardLine splits lines longer than 75 octets as required by RFC 6350
+func foldVCardLine(line string) string {
+	var b strings.Builder
+	width := 0
+	for _, r := range line {
+		size := len(string(r))
+		if width+size > 75 {
+			b.WriteString("\r\n ")
+			width = 1
+		}
+		b.WriteRune(r)
+		width += size
+	}
+	return b.String()
+}
+
+func buildResumePDF(profile *exportProfile) []byte {
+	user := profile.user
+	doc := utils.NewPDFDocument()
+
+	doc.Title(user.Name)
+	if user.Position != "" && user.Company != "" {
+		doc.Text(user.Position + " at " + user.Company)
+	} else if user.Position != "" || user.Company != "" {
+		doc.Text(user.Position + user.Company)
+	}
+
+	var contact []string
+	for _, value := range []string{user.Email, user.Location, user.GitHubURL, user.LinkedInURL} {
+		if value != "" {
+			contact = append(contact, value)
+		}
+	}
+	if len(contact) > 0 {
+		doc.Text(strings.Join(contact, "  |  "))
+	}
+
+	if profile.bio != "" {
+		doc.Heading("Summary")
+		doc.Text(profile.bio)
+	}
+
+	if user.Experience != "" {
+		doc.Heading("Experience")
+		doc.Text(user.Experience)
+	}
+
+	doc.Heading("Education")
+	education := exportDepartment + ", " + exportInstitution
+	if user.GraduationYear != 0 {
+		education += " (" + strconv.Itoa(user.GraduationYear) + ")"
+	}
+	doc.Text(education)
+	if user.CGPA != 0 {
+		doc.Text(fmt.Sprintf("CGPA: %.2f", user.CGPA))
+	}
+
+	if len(user.Skills) > 0 {
+		doc.Heading("Skills")
+		doc.Text(strings.Join(user.Skills, ", "))
+	}
+
+	if len(profile.projects) > 0 {
+		doc.Heading("Projects")
+		for _, project := range profile.projects {
+			doc.Space(4)
+			doc.Text(project.Title + " (" + string(project.ProjectType) + " project, " + project.CreatedAt.Format("Jan 2006") + ")")
+			doc.Text(project.Description)
+			if len(project.Technologies) > 0 {
+				doc.Text("Technologies: " + strings.Join(project.Technologies, ", "))
+			}
+			if project.GitHubURL != "" {
+				doc.Text(project.GitHubURL)
+			}
+		}
+	}
+
+	return doc.Bytes()
+}
+
+func exportFilename(name string) string {
+	filename := strings.Trim(exportFilenameUnsafe.ReplaceAllString(strings.ToLower(name), "-"), "-")
+	if filename == "" {
+		return "profile"
+	}
+	return filename
+}
@@ -2,7 +2,9 @@ package handlers
 
 import (
 	"context"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
@@ -27,6 +29,9 @@ func NewJobHandler() *JobHandler {
 }
 
 func (h *JobHandler) GetJobs(c *fiber.Ctx) error {
+	userID := middleware.GetUserID(c)
+	userRole := middleware.GetUserRole(c)
+
 	// Parse query parameters
 	page, _ := strconv.Atoi(c.Query("page", "1"))
 	limit, _ := strconv.Atoi(c.Query("limit", "20"))
@@ -34,6 +39,8 @@ func (h *JobHandler) GetJobs(c *fiber.Ctx) error {
 	location := c.Query("location")
 	search := c.Query("search")
 	postedBy := c.Query("posted_by")
+	includeExpired := c.Query("include_expired") == "true"
+	sortBy := c.Query("sort")
 
 	if page < 1 {
 		page = 1
@@ -42,13 +49,21 @@ func (h *JobHandler) GetJobs(c *fiber.Ctx) error {
 		limit = 20
 	}
 
+	collection := config.GetCollection("jobs")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
 	// Build filter
-	filter := bson.M{
-		"is_active": true,
-		"$or": []bson.M{
-			{"expires_at": bson.M{"$gt": time.Now()}},
-			{"expires_at": nil},
-		},
+	filter := visibleJobFilter()
+	if includeExpired {
+		var err error
+		filter, err = listedJobFilterFor(ctx, userID, userRole)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error":   true,
+				"message": "Failed to fetch jobs",
+			})
+		}
 	}
 
 	if jobType != "" {
@@ -67,7 +82,6 @@ func (h *JobHandler) GetJobs(c *fiber.Ctx) error {
 
 	if search != "" {
 		filter["$and"] = []bson.M{
-			filter,
 			{
 				"$or": []bson.M{
 					{"title": bson.M{"$regex": search, "$options": "i"}},
@@ -79,10 +93,6 @@ func (h *JobHandler) GetJobs(c *fiber.Ctx) error {
 		}
 	}
 
-	collection := config.GetCollection("jobs")
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-
 	// Get total count
 	total, err := collection.CountDocuments(ctx, filter)
 	if err != nil {
@@ -92,12 +102,26 @@ func (h *JobHandler) GetJobs(c *fiber.Ctx) error {
 		})
 	}
 
+	// Students see how well each job matches their skills. Ranking by best
+	// match scores every job that passes the filter, then paginates.
+	bestMatch := sortBy == "best_match"
+	var skills map[string]string
+	if userRole == models.RoleStudent || bestMatch {
+		skills, err = userSkillSet(ctx, userID)
+		if err != nil && bestMatch {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error":   true,
+				"message": "Failed to load your skills",
+			})
+		}
+	}
+
 	// Get jobs with pagination
 	skip := (page - 1) * limit
-	opts := options.Find().
-		SetSkip(int64(skip)).
-		SetLimit(int64(limit)).
-		SetSort(bson.M{"created_at": -1})
+	opts := options.Find().SetSort(bson.M{"created_at": -1})
+	if !bestMatch {
+		opts.SetSkip(int64(skip)).SetLimit(int64(limit))
+	}
 
 	cursor, err := collection.Find(ctx, filter, opts)
 	if err != nil {
@@ -116,6 +140,18 @@ func (h *JobHandler) GetJobs(c *fiber.Ctx) error {
 		})
 	}
 
+	if skills != nil || bestMatch {
+		for i := range jobs {
+			jobs[i].Match = models.ScoreSkillMatch(skills, &jobs[i])
+		}
+	}
+	if bestMatch {
+		sort.SliceStable(jobs, func(i, j int) bool {
+			return jobs[i].Match.Score > jobs[j].Match.Score
+		})
+		jobs = jobs[min(skip, len(jobs)):min(skip+limit, len(jobs))]
+	}
+
 	// Populate posted by user information
 	userCollection := config.GetCollection("users")
 	for i := range jobs {
@@ -168,6 +204,7 @@ func (h *JobHandler) CreateJob(c *fiber.Ctx) error {
 		SalaryRange:        utils.SanitizeString(req.SalaryRange),
 		Description:        utils.SanitizeString(req.Description),
 		Requirements:       req.Requirements,
+		ScreeningQuestions: screeningQuestions(req.ScreeningQuestions),
 		PostedBy:           userID,
 		ApplicantsCount:    0,
 		IsActive:           true,
@@ -179,6 +216,15 @@ func (h *JobHandler) CreateJob(c *fiber.Ctx) error {
 		job.ExpiresAt = &req.ExpiresAt
 	}
 
+	// Structured compensation also fills the free text salary range
+	if req.Compensation != nil {
+		req.Compensation.Currency = strings.ToUpper(req.Compensation.Currency)
+		job.Compensation = req.Compensation
+		if job.SalaryRange == "" {
+			job.SalaryRange = req.Compensation.String()
+		}
+	}
+
 	collection := config.GetCollection("jobs")
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
@@ -191,8 +237,8 @@ func (h *JobHandler) CreateJob(c *fiber.Ctx) error {
 		})
 	}
 
-	// Send notifications to all students
-	go h.notifyStudentsAboutNewJob(job)
+	// Alert users whose saved searches match
+	go h.notifySavedSearchMatches(job)
 
 	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
 		"error":   false,
@@ -201,71 +247,6 @@ func (h *JobHandler) CreateJob(c *fiber.Ctx) error {
 	})
 }
 
-func (h *JobHandler) notifyStudentsAboutNewJob(job models.Job) {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
-
-	// Get all students
-	usersCollection := config.GetCollection("users")
-	cursor, err := usersCollection.Find(ctx, bson.M{
-		"role":        models.RoleStudent,
-		"is_verified": true,
-		"is_active":   true,
-	})
-	if err != nil {
-		return
-	}
-	defer cursor.Close(ctx)
-
-	var students []models.User
-	cursor.All(ctx, &students)
-
-	// Get job poster info
-	var poster models.User
-	usersCollection.FindOne(ctx, bson.M{"_id": job.PostedBy}).Decode(&poster)
-
-	// Create notifications and send emails
-	notificationsCollection := config.GetCollection("notifications")
-	for _, student := range students {
-		// Create notification
-		notification := models.Notification{
-			ID:               primitive.NewObjectID(),
-			UserID:           student.ID,
-			Title:            "New Job Posted",
-			Message:          "A new " + job.Title + " position has been posted at " + job.Company,
-			NotificationType: models.NotificationJobPosted,
-			RelatedID:        &job.ID,
-			IsRead:           false,
-			CreatedAt:        time.Now(),
-		}
-
-		notificationsCollection.InsertOne(ctx, notification)
-
-		// Send email notification
-		emailSubject := "New Job Opportunity on AlmaniPortal"
-		emailBody := `Hello ` + student.Name + `,
-
-A new job opportunity has been posted on AlmaniPortal:
-
-Position: ` + job.Title + `
-Company: ` + job.Company + `
-Location: ` + job.Location + `
-Posted by: ` + poster.Name + `
-
-Description:
-` + job.Description + `
-
-Visit AlmaniPortal to view full details and show your interest.
-
-Thanks,
-AlmaniPortal Team
-Electronics and Telecommunication Department
-Dr. AIT, Bengaluru`
-
-		h.emailService.SendJobNotification(student.Email, job.Title, job.Company, poster.Name, emailSubject, emailBody)
-	}
-}
-
 func (h *JobHandler) GetJobByID(c *fiber.Ctx) error {
 	jobIDStr := c.Params("id")
 	jobID, err := primitive.ObjectIDFromHex(jobIDStr)
@@ -280,12 +261,13 @@ func (h *JobHandler) GetJobByID(c *fiber.Ctx) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
+	// Expired jobs stay viewable for their poster, admins and applicants
+	filter := listedJobFilter()
+	filter["_id"] = jobID
+
 	var job models.Job
-	err = collection.FindOne(ctx, bson.M{
-		"_id":       jobID,
-		"is_active": true,
-	}).Decode(&job)
-	if err != nil {
+	err = collection.FindOne(ctx, filter).Decode(&job)
+	if err != nil || !canViewJob(ctx, &job, middleware.GetUserID(c), middleware.GetUserRole(c)) {
 		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
 			"error":   true,
 			"message": "Job not found",
@@ -300,6 +282,12 @@ func (h *JobHandler) GetJobByID(c *fiber.Ctx) error {
 		job.PostedByUser = user.ToResponse()
 	}
 
+	if middleware.GetUserRole(c) == models.RoleStudent {
+		if skills, err := userSkillSet(ctx, middleware.GetUserID(c)); err == nil {
+			job.Match = models.ScoreSkillMatch(skills, &job)
+		}
+	}
+
 	return c.JSON(fiber.Map{
 		"error": false,
 		"data":  job,
@@ -337,12 +325,12 @@ func (h *JobHandler) UpdateJob(c *fiber.Ctx) error {
 	defer cancel()
 
 	// Check if job exists and user owns it
+	filter := listedJobFilter()
+	filter["_id"] = jobID
+	filter["posted_by"] = userID
+
 	var existingJob models.Job
-	err = collection.FindOne(ctx, bson.M{
-		"_id":       jobID,
-		"posted_by": userID,
-		"is_active": true,
-	}).Decode(&existingJob)
+	err = collection.FindOne(ctx, filter).Decode(&existingJob)
 	if err != nil {
 		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
 			"error":   true,
@@ -375,14 +363,33 @@ func (h *JobHandler) UpdateJob(c *fiber.Ctx) error {
 	if req.SalaryRange != "" {
 		update["$set"].(bson.M)["salary_range"] = utils.SanitizeString(req.SalaryRange)
 	}
+	if req.Compensation != nil {
+		req.Compensation.Currency = strings.ToUpper(req.Compensation.Currency)
+		update["$set"].(bson.M)["compensation"] = req.Compensation
+		if req.SalaryRange == "" {
+			update["$set"].(bson.M)["salary_range"] = req.Compensation.String()
+		}
+	}
 	if req.Description != "" {
 		update["$set"].(bson.M)["description"] = utils.SanitizeString(req.Description)
 	}
 	if req.Requirements != nil {
 		update["$set"].(bson.M)["requirements"] = req.Requirements
 	}
+	if req.ScreeningQuestions != nil {
+		update["$set"].(bson.M)["screening_questions"] = screeningQuestions(req.ScreeningQuestions)
+	}
 	if !req.ExpiresAt.IsZero() {
+		// A new expiry date gets its own reminder and revives an expired job
 		update["$set"].(bson.M)["expires_at"] = req.ExpiresAt
+		update["$unset"] = bson.M{
+			"expiry_reminded_at": "",
+			"extend_token_hash":  "",
+		}
+		if existingJob.ExpiredAt != nil && req.ExpiresAt.After(time.Now()) {
+			update["$set"].(bson.M)["is_active"] = true
+			update["$unset"].(bson.M)["expired_at"] = ""
+		}
 	}
 
 	var job models.Job
@@ -422,21 +429,21 @@ func (h *JobHandler) DeleteJob(c *fiber.Ctx) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	filter := bson.M{
-		"_id":       jobID,
-		"is_active": true,
-	}
+	filter := listedJobFilter()
+	filter["_id"] = jobID
 
 	// Only allow poster or admin to delete
 	if userRole != models.RoleAdmin {
 		filter["posted_by"] = userID
 	}
 
+	// Clearing expired_at keeps deleted jobs out of expired listings
 	_, err = collection.UpdateOne(ctx, filter, bson.M{
 		"$set": bson.M{
 			"is_active":  false,
 			"updated_at": time.Now(),
 		},
+		"$unset": bson.M{"expired_at": ""},
 	})
 	if err != nil {
 		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
@@ -450,207 +457,3 @@ func (h *JobHandler) DeleteJob(c *fiber.Ctx) error {
 		"message": "Job deleted successfully",
 	})
 }
-
-func (h *JobHandler) ShowInterest(c *fiber.Ctx) error {
-	userID := middleware.GetUserID(c)
-	jobIDStr := c.Params("id")
-	jobID, err := primitive.ObjectIDFromHex(jobIDStr)
-	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error":   true,
-			"message": "Invalid job ID",
-		})
-	}
-
-	interestsCollection := config.GetCollection("job_interests")
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-
-	// Check if already interested
-	var existingInterest models.JobInterest
-	err = interestsCollection.FindOne(ctx, bson.M{
-		"job_id":  jobID,
-		"user_id": userID,
-	}).Decode(&existingInterest)
-	if err == nil {
-		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
-			"error":   true,
-			"message": "Already showed interest in this job",
-		})
-	}
-
-	// Create interest
-	interest := models.JobInterest{
-		ID:        primitive.NewObjectID(),
-		JobID:     jobID,
-		UserID:    userID,
-		CreatedAt: time.Now(),
-	}
-
-	_, err = interestsCollection.InsertOne(ctx, interest)
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error":   true,
-			"message": "Failed to show interest",
-		})
-	}
-
-	// Increment applicants count
-	jobsCollection := config.GetCollection("jobs")
-	_, err = jobsCollection.UpdateOne(ctx, bson.M{"_id": jobID}, bson.M{
-		"$inc": bson.M{"applicants_count": 1},
-	})
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error":   true,
-			"message": "Failed to update applicants count",
-		})
-	}
-
-	// Notify job poster
-	go h.notifyJobPosterAboutInterest(jobID, userID)
-
-	return c.JSON(fiber.Map{
-		"error":   false,
-		"message": "Interest shown successfully",
-	})
-}
-
-func (h *JobHandler) notifyJobPosterAboutInterest(jobID, userID primitive.ObjectID) {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-
-	// Get job and user info
-	jobsCollection := config.GetCollection("jobs")
-	usersCollection := config.GetCollection("users")
-
-	var job models.Job
-	var user models.User
-
-	jobsCollection.FindOne(ctx, bson.M{"_id": jobID}).Decode(&job)
-	usersCollection.FindOne(ctx, bson.M{"_id": userID}).Decode(&user)
-
-	// Create notification for job poster
-	notification := models.Notification{
-		ID:               primitive.NewObjectID(),
-		UserID:           job.PostedBy,
-		Title:            "New Interest Received",
-		Message:          user.Name + " has shown interest in your " + job.Title + " position",
-		NotificationType: models.NotificationInterestReceived,
-		RelatedID:        &jobID,
-		IsRead:           false,
-		CreatedAt:        time.Now(),
-	}
-
-	notificationsCollection := config.GetCollection("notifications")
-	notificationsCollection.InsertOne(ctx, notification)
-}
-
-func (h *JobHandler) RemoveInterest(c *fiber.Ctx) error {
-	userID := middleware.GetUserID(c)
-	jobIDStr := c.Params("id")
-	jobID, err := primitive.ObjectIDFromHex(jobIDStr)
-	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error":   true,
-			"message": "Invalid job ID",
-		})
-	}
-
-	interestsCollection := config.GetCollection("job_interests")
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-
-	// Remove interest
-	result, err := interestsCollection.DeleteOne(ctx, bson.M{
-		"job_id":  jobID,
-		"user_id": userID,
-	})
-	if err != nil || result.DeletedCount == 0 {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-			"error":   true,
-			"message": "Interest not found",
-		})
-	}
-
-	// Decrement applicants count
-	jobsCollection := config.GetCollection("jobs")
-	_, err = jobsCollection.UpdateOne(ctx, bson.M{"_id": jobID}, bson.M{
-		"$inc": bson.M{"applicants_count": -1},
-	})
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error":   true,
-			"message": "Failed to update applicants count",
-		})
-	}
-
-	return c.JSON(fiber.Map{
-		"error":   false,
-		"message": "Interest removed successfully",
-	})
-}
-
-func (h *JobHandler) GetInterestedUsers(c *fiber.Ctx) error {
-	userID := middleware.GetUserID(c)
-	jobIDStr := c.Params("id")
-	jobID, err := primitive.ObjectIDFromHex(jobIDStr)
-	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error":   true,
-			"message": "Invalid job ID",
-		})
-	}
-
-	// Check if user owns the job
-	jobsCollection := config.GetCollection("jobs")
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-
-	var job models.Job
-	err = jobsCollection.FindOne(ctx, bson.M{
-		"_id":       jobID,
-		"posted_by": userID,
-		"is_active": true,
-	}).Decode(&job)
-	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-			"error":   true,
-			"message": "Job not found or access denied",
-		})
-	}
-
-	// Get interested users
-	interestsCollection := config.GetCollection("job_interests")
-	cursor, err := interestsCollection.Find(ctx, bson.M{"job_id": jobID})
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error":   true,
-			"message": "Failed to fetch interested users",
-		})
-	}
-	defer cursor.Close(ctx)
-
-	var interests []models.JobInterest
-	if err = cursor.All(ctx, &interests); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error":   true,
-			"message": "Failed to decode interests",
-		})
-	}
-
-	// Populate user information
-	usersCollection := config.GetCollection("users")
-	for i := range interests {
-		var user models.User
-		err := usersCollection.FindOne(ctx, bson.M{"_id": interests[i].UserID}).Decode(&user)
-		if err == nil {
-			interests[i].User = user.ToResponse()
-		}
-	}
-
-	return c.JSON(fiber.Map{
-		"error": false,
-		"data":  interests,
-	})
-}
@@ -0,0 +1,673 @@
+package handlers
+
+import (
+	"context"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"ete-alumni-portal/config"
+	"ete-alumni-portal/middleware"
+	"ete-alumni-portal/models"
+	"ete-alumni-portal/utils"
+)
+
+// applicationStatusMessages is the notification text sent to applicants for
+// each stage, completed with the job title and company
+var applicationStatusMessages = map[models.ApplicationStatus]string{
+	models.ApplicationShortlisted: "You have been shortlisted for ",
+	models.ApplicationInterview:   "You have been invited to interview for ",
+	models.ApplicationOffered:     "You have received an offer for ",
+	models.ApplicationRejected:    "Your application was not selected for ",
+}
+
+// screeningQuestions turns request questions into stored ones with IDs
+func screeningQuestions(reqs []models.ScreeningQuestionRequest) []models.ScreeningQuestion {
+	questions := []models.ScreeningQuestion{}
+	for _, req := range reqs {
+		questions = append(questions, models.ScreeningQuestion{
+			ID:       primitive.NewObjectID(),
+			Question: utils.SanitizeString(req.Question),
+			Required: req.Required,
+		})
+	}
+	return questions
+}
+
+// screeningAnswers matches answers to the job's questions. Every required
+// question must be answered and unknown questions are rejected.
+func screeningAnswers(job *models.Job, reqs []models.ScreeningAnswerRequest) ([]models.ScreeningAnswer, error) {
+	given := map[string]string{}
+	for _, req := range reqs {
+		given[req.QuestionID] = strings.TrimSpace(req.Answer)
+	}
+
+	answers := []models.ScreeningAnswer{}
+	for _, question := range job.ScreeningQuestions {
+		answer := given[question.ID.Hex()]
+		delete(given, question.ID.Hex())
+		if answer == "" {
+			if question.Required {
+				return nil, fiber.NewError(fiber.StatusBadRequest, "Please answer: "+question.Question)
+			}
+			continue
+		}
+		answers = append(answers, models.ScreeningAnswer{
+			QuestionID: question.ID,
+			Question:   question.Question,
+			Answer:     utils.SanitizeString(answer),
+		})
+	}
+
+	if len(given) > 0 {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Unknown screening question")
+	}
+	return answers, nil
+}
+
+// ApplyToJob submits an application with an optional cover note, resume and
+// screening answers. The applicant's latest resume is attached when none is
+// chosen. A withdrawn application can be submitted again.
+func (h *JobHandler) ApplyToJob(c *fiber.Ctx) error {
+	userID := middleware.GetUserID(c)
+	jobID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid job ID",
+		})
+	}
+
+	// The old one-click interest route posts no body
+	var req models.ApplyJobRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   true,
+				"message": "Invalid request body",
+			})
+		}
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": err.Error(),
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var job models.Job
+	err = config.GetCollection("jobs").FindOne(ctx, bson.M{
+		"_id":       jobID,
+		"is_active": true,
+	}).Decode(&job)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "Job not found",
+		})
+	}
+
+	if job.ExpiresAt != nil && job.ExpiresAt.Before(time.Now()) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "This job is no longer accepting applications",
+		})
+	}
+
+	answers, err := screeningAnswers(&job, req.Answers)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": err.Error(),
+		})
+	}
+
+	// Attach the chosen resume, or the latest one
+	resumeFilter := bson.M{"user_id": userID}
+	if req.ResumeID != "" {
+		resumeID, err := primitive.ObjectIDFromHex(req.ResumeID)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   true,
+				"message": "Invalid resume ID",
+			})
+		}
+		resumeFilter["_id"] = resumeID
+	}
+
+	var resume models.Resume
+	err = config.GetCollection("resumes").FindOne(ctx, resumeFilter,
+		options.FindOne().SetSort(bson.M{"version": -1}),
+	).Decode(&resume)
+	if err != nil && req.ResumeID != "" {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "Resume not found",
+		})
+	}
+
+	now := time.Now()
+	application := models.JobApplication{
+		ID:        primitive.NewObjectID(),
+		JobID:     jobID,
+		UserID:    userID,
+		CoverNote: utils.SanitizeString(req.CoverNote),
+		Answers:   answers,
+		Status:    models.ApplicationApplied,
+		History: []models.ApplicationStatusChange{{
+			Status:    models.ApplicationApplied,
+			ChangedBy: userID,
+			ChangedAt: now,
+		}},
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err == nil {
+		application.ResumeID = &resume.ID
+	}
+
+	collection := config.GetCollection("job_applications")
+
+	var existing models.JobApplication
+	err = collection.FindOne(ctx, bson.M{
+		"job_id":  jobID,
+		"user_id": userID,
+	}).Decode(&existing)
+	if err == nil {
+		if existing.Status != models.ApplicationWithdrawn {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error":   true,
+				"message": "Already applied to this job",
+			})
+		}
+
+		application.ID = existing.ID
+		application.CreatedAt = existing.CreatedAt
+		application.History = append(existing.History, application.History...)
+		_, err = collection.ReplaceOne(ctx, bson.M{
+			"_id":    existing.ID,
+			"status": models.ApplicationWithdrawn,
+		}, application)
+	} else {
+		_, err = collection.InsertOne(ctx, application)
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to submit application",
+		})
+	}
+
+	// Increment applicants count
+	_, err = config.GetCollection("jobs").UpdateOne(ctx, bson.M{"_id": jobID}, bson.M{
+		"$inc": bson.M{"applicants_count": 1},
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to update applicants count",
+		})
+	}
+
+	// Notify job poster
+	go h.notifyJobPosterAboutApplication(job, userID)
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"error":   false,
+		"message": "Application submitted successfully",
+		"data":    application,
+	})
+}
+
+func (h *JobHandler) notifyJobPosterAboutApplication(job models.Job, userID primitive.ObjectID) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var user models.User
+	config.GetCollection("users").FindOne(ctx, bson.M{"_id": userID}).Decode(&user)
+
+	createNotification(ctx, job.PostedBy, "New Application Received",
+		user.Name+" has applied to your "+job.Title+" position",
+		models.NotificationInterestReceived, &job.ID, "job")
+}
+
+// WithdrawApplication lets the applicant leave the pipeline at any open stage
+func (h *JobHandler) WithdrawApplication(c *fiber.Ctx) error {
+	userID := middleware.GetUserID(c)
+	jobID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid job ID",
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	result, err := config.GetCollection("job_applications").UpdateOne(ctx, bson.M{
+		"job_id":  jobID,
+		"user_id": userID,
+		"status": bson.M{"$nin": []models.ApplicationStatus{
+			models.ApplicationRejected,
+			models.ApplicationWithdrawn,
+		}},
+	}, bson.M{
+		"$set": bson.M{
+			"status":     models.ApplicationWithdrawn,
+			"updated_at": now,
+		},
+		"$push": bson.M{"history": models.ApplicationStatusChange{
+			Status:    models.ApplicationWithdrawn,
+			ChangedBy: userID,
+			ChangedAt: now,
+		}},
+	})
+	if err != nil || result.MatchedCount == 0 {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "Application not found",
+		})
+	}
+
+	// Decrement applicants count
+	_, err = config.GetCollection("jobs").UpdateOne(ctx, bson.M{"_id": jobID}, bson.M{
+		"$inc": bson.M{"applicants_count": -1},
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to update applicants count",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"error":   false,
+		"message": "Application withdrawn successfully",
+	})
+}
+
+// findPostedJob loads a job the user may manage applications for: its poster,
+// or any admin. Hiring can continue after the posting expires.
+func findPostedJob(ctx context.Context, c *fiber.Ctx) (*models.Job, error) {
+	jobID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return nil, c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid job ID",
+		})
+	}
+
+	filter := listedJobFilter()
+	filter["_id"] = jobID
+	if middleware.GetUserRole(c) != models.RoleAdmin {
+		filter["posted_by"] = middleware.GetUserID(c)
+	}
+
+	var job models.Job
+	if err := config.GetCollection("jobs").FindOne(ctx, filter).Decode(&job); err != nil {
+		return nil, c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "Job not found or access denied",
+		})
+	}
+	return &job, nil
+}
+
+// GetJobApplications lists a job's applicants for its poster, optionally
+// filtered by status. Each applicant's skills are scored against the job;
+// sort=best_match ranks applicants by that score.
+func (h *JobHandler) GetJobApplications(c *fiber.Ctx) error {
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+	status := c.Query("status")
+	bestMatch := c.Query("sort") == "best_match"
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	job, err := findPostedJob(ctx, c)
+	if job == nil {
+		return err
+	}
+
+	filter := bson.M{"job_id": job.ID}
+	if status != "" {
+		filter["status"] = status
+	}
+
+	collection := config.GetCollection("job_applications")
+	total, err := collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to count applications",
+		})
+	}
+
+	skip := (page - 1) * limit
+	opts := options.Find().SetSort(bson.M{"created_at": -1})
+	if !bestMatch {
+		opts.SetSkip(int64(skip)).SetLimit(int64(limit))
+	}
+
+	cursor, err := collection.Find(ctx, filter, opts)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to fetch applications",
+		})
+	}
+	defer cursor.Close(ctx)
+
+	applications := []models.JobApplication{}
+	if err = cursor.All(ctx, &applications); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to decode applications",
+		})
+	}
+
+	for i := range applications {
+		skills, _ := userSkillSet(ctx, applications[i].UserID)
+		applications[i].Match = models.ScoreSkillMatch(skills, job)
+	}
+	if bestMatch {
+		sort.SliceStable(applications, func(i, j int) bool {
+			return applications[i].Match.Score > applications[j].Match.Score
+		})
+		applications = applications[min(skip, len(applications)):min(skip+limit, len(applications))]
+	}
+
+	// Populate applicant and resume information
+	usersCollection := config.GetCollection("users")
+	resumesCollection := config.GetCollection("resumes")
+	for i := range applications {
+		var user models.User
+		if err := usersCollection.FindOne(ctx, bson.M{"_id": applications[i].UserID}).Decode(&user); err == nil {
+			applications[i].User = user.ToResponse()
+		}
+		if applications[i].ResumeID != nil {
+			var resume models.Resume
+			if err := resumesCollection.FindOne(ctx, bson.M{"_id": applications[i].ResumeID}).Decode(&resume); err == nil {
+				applications[i].Resume = &resume
+			}
+		}
+	}
+
+	return c.JSON(fiber.Map{
+		"error": false,
+		"data": fiber.Map{
+			"applications": applications,
+			"pagination": fiber.Map{
+				"page":        page,
+				"limit":       limit,
+				"total":       total,
+				"total_pages": (total + int64(limit) - 1) / int64(limit),
+			},
+		},
+	})
+}
+
+// GetInterestedUsers serves the old interested-users route for clients that
+// predate applications. It keeps that route's response: a plain list of
+// interests built from the job's open applications.
+func (h *JobHandler) GetInterestedUsers(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	job, err := findPostedJob(ctx, c)
+	if job == nil {
+		return err
+	}
+
+	cursor, err := config.GetCollection("job_applications").Find(ctx, bson.M{
+		"job_id": job.ID,
+		"status": bson.M{"$ne": models.ApplicationWithdrawn},
+	}, options.Find().SetSort(bson.M{"created_at": -1}))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to fetch interested users",
+		})
+	}
+	defer cursor.Close(ctx)
+
+	var applications []models.JobApplication
+	if err = cursor.All(ctx, &applications); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to decode interests",
+		})
+	}
+
+	usersCollection := config.GetCollection("users")
+	interests := []models.JobInterest{}
+	for _, application := range applications {
+		interest := models.JobInterest{
+			ID:        application.ID,
+			JobID:     application.JobID,
+			UserID:    application.UserID,
+			CreatedAt: application.CreatedAt,
+		}
+		var user models.User
+		if err := usersCollection.FindOne(ctx, bson.M{"_id": application.UserID}).Decode(&user); err == nil {
+			interest.User = user.ToResponse()
+		}
+		interests = append(interests, interest)
+	}
+
+	return c.JSON(fiber.Map{
+		"error": false,
+		"data":  interests,
+	})
+}
+
+// UpdateApplicationStatus moves a candidate to the next pipeline stage and
+// notifies them
+func (h *JobHandler) UpdateApplicationStatus(c *fiber.Ctx) error {
+	userID := middleware.GetUserID(c)
+	applicationID, err := primitive.ObjectIDFromHex(c.Params("applicationId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid application ID",
+		})
+	}
+
+	var req models.UpdateApplicationStatusRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid request body",
+		})
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": err.Error(),
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	job, err := findPostedJob(ctx, c)
+	if job == nil {
+		return err
+	}
+
+	collection := config.GetCollection("job_applications")
+
+	var application models.JobApplication
+	err = collection.FindOne(ctx, bson.M{
+		"_id":    applicationID,
+		"job_id": job.ID,
+	}).Decode(&application)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "Application not found",
+		})
+	}
+
+	if !application.Status.CanMoveTo(req.Status) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Cannot move an application from " + string(application.Status) + " to " + string(req.Status),
+		})
+	}
+
+	// Match on the current status so concurrent moves cannot skip a stage
+	now := time.Now()
+	err = collection.FindOneAndUpdate(ctx, bson.M{
+		"_id":    applicationID,
+		"status": application.Status,
+	}, bson.M{
+		"$set": bson.M{
+			"status":     req.Status,
+			"updated_at": now,
+		},
+		"$push": bson.M{"history": models.ApplicationStatusChange{
+			Status:    req.Status,
+			ChangedBy: userID,
+			Note:      utils.SanitizeString(req.Note),
+			ChangedAt: now,
+		}},
+	}, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&application)
+	if err != nil {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error":   true,
+			"message": "Application was updated by someone else, please reload",
+		})
+	}
+
+	createNotification(ctx, application.UserID, "Application Update",
+		applicationStatusMessages[req.Status]+job.Title+" at "+job.Company,
+		models.NotificationJobApplication, &application.ID, "job_application")
+
+	return c.JSON(fiber.Map{
+		"error":   false,
+		"message": "Application status updated successfully",
+		"data":    application,
+	})
+}
+
+// GetMyApplications lists the user's applications with their jobs
+func (h *JobHandler) GetMyApplications(c *fiber.Ctx) error {
+	userID := middleware.GetUserID(c)
+	status := c.Query("status")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{"user_id": userID}
+	if status != "" {
+		filter["status"] = status
+	}
+
+	cursor, err := config.GetCollection("job_applications").Find(ctx, filter,
+		options.Find().SetSort(bson.M{"updated_at": -1}))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to fetch applications",
+		})
+	}
+	defer cursor.Close(ctx)
+
+	applications := []models.JobApplication{}
+	if err = cursor.All(ctx, &applications); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to decode applications",
+		})
+	}
+
+	// Populate job information
+	jobsCollection := config.GetCollection("jobs")
+	for i := range applications {
+		var job models.Job
+		if err := jobsCollection.FindOne(ctx, bson.M{"_id": applications[i].JobID}).Decode(&job); err == nil {
+			applications[i].Job = &job
+		}
+	}
+
+	return c.JSON(fiber.Map{
+		"error": false,
+		"data":  applications,
+	})
+}
+
+// MigrateJobInterests turns one-click job interests from before applications
+// existed into applied applications. It is safe to run on every start.
+func MigrateJobInterests() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	interests := config.GetCollection("job_interests")
+	cursor, err := interests.Find(ctx, bson.M{})
+	if err != nil {
+		log.Printf("Failed to load job interests for migration: %v", err)
+		return
+	}
+	defer cursor.Close(ctx)
+
+	applications := config.GetCollection("job_applications")
+	migrated := 0
+	for cursor.Next(ctx) {
+		var interest models.JobInterest
+		if err := cursor.Decode(&interest); err != nil {
+			continue
+		}
+
+		_, err := applications.UpdateOne(ctx, bson.M{
+			"job_id":  interest.JobID,
+			"user_id": interest.UserID,
+		}, bson.M{
+			"$setOnInsert": bson.M{
+				"_id":     primitive.NewObjectID(),
+				"answers": []models.ScreeningAnswer{},
+				"status":  models.ApplicationApplied,
+				"history": []models.ApplicationStatusChange{{
+					Status:    models.ApplicationApplied,
+					ChangedBy: interest.UserID,
+					ChangedAt: interest.CreatedAt,
+				}},
+				"created_at": interest.CreatedAt,
+				"updated_at": interest.CreatedAt,
+			},
+		}, options.Update().SetUpsert(true))
+		if err != nil {
+			log.Printf("Failed to migrate job interest %s: %v", interest.ID.Hex(), err)
+			continue
+		}
+
+		interests.DeleteOne(ctx, bson.M{"_id": interest.ID})
+		migrated++
+	}
+
+	if migrated > 0 {
+		log.Printf("Migrated %d job interests to applications", migrated)
+	}
+}
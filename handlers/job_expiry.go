@@ -0,0 +1,256 @@
+package handlers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"log"
+	"net/url"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"ete-alumni-portal/config"
+	"ete-alumni-portal/models"
+	"ete-alumni-portal/utils"
+)
+
+// visibleJobFilter matches jobs that are open: active and not past their
+// expiry date, which the worker may not have caught up with yet
+func visibleJobFilter() bson.M {
+	return bson.M{
+		"is_active": true,
+		"$or": []bson.M{
+			{"expires_at": bson.M{"$gt": time.Now()}},
+			{"expires_at": nil},
+		},
+	}
+}
+
+// listedJobFilter matches jobs that were not deleted. Expired jobs are
+// deactivated but keep their expired_at date.
+func listedJobFilter() bson.M {
+	return bson.M{
+		"$or": []bson.M{
+			{"is_active": true},
+			{"expired_at": bson.M{"$ne": nil}},
+		},
+	}
+}
+
+// listedJobFilterFor matches the listed jobs the user may open, following
+// canViewJob: open jobs, plus expired jobs they posted or applied to. Admins
+// see every listed job.
+func listedJobFilterFor(ctx context.Context, userID primitive.ObjectID, role models.UserRole) (bson.M, error) {
+	if role == models.RoleAdmin {
+		return listedJobFilter(), nil
+	}
+
+	applied, err := config.GetCollection("job_applications").Distinct(ctx, "job_id", bson.M{"user_id": userID})
+	if err != nil {
+		return nil, err
+	}
+
+	return bson.M{
+		"$or": []bson.M{
+			visibleJobFilter(),
+			{"$and": []bson.M{
+				listedJobFilter(),
+				{"$or": []bson.M{
+					{"posted_by": userID},
+					{"_id": bson.M{"$in": applied}},
+				}},
+			}},
+		},
+	}, nil
+}
+
+// canViewJob reports whether the user may open a listed job. Expired jobs are
+// only kept viewable for their poster, admins and users who applied.
+func canViewJob(ctx context.Context, job *models.Job, userID primitive.ObjectID, role models.UserRole) bool {
+	expired := !job.IsActive || (job.ExpiresAt != nil && !job.ExpiresAt.After(time.Now()))
+	if !expired || job.PostedBy == userID || role == models.RoleAdmin {
+		return true
+	}
+
+	applied, err := config.GetCollection("job_applications").CountDocuments(ctx, bson.M{
+		"job_id":  job.ID,
+		"user_id": userID,
+	})
+	return err == nil && applied > 0
+}
+
+// ProcessJobExpiry periodically reminds posters of jobs about to expire and
+// deactivates expired ones
+func ProcessJobExpiry() {
+	ticker := time.NewTicker(1 * time.Hour)
+	defer ticker.Stop()
+
+	emailService := utils.NewEmailService()
+	for {
+		remindExpiringJobs(emailService)
+		deactivateExpiredJobs()
+		<-ticker.C
+	}
+}
+
+func remindExpiringJobs(emailService *utils.EmailService) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	cfg := config.GetConfig()
+	now := time.Now()
+	collection := config.GetCollection("jobs")
+	cursor, err := collection.Find(ctx, bson.M{
+		"is_active":          true,
+		"expires_at":         bson.M{"$gt": now, "$lte": now.Add(cfg.JobExpiryReminder)},
+		"expiry_reminded_at": nil,
+	})
+	if err != nil {
+		log.Printf("Failed to fetch expiring jobs: %v", err)
+		return
+	}
+	defer cursor.Close(ctx)
+
+	var jobs []models.Job
+	if err = cursor.All(ctx, &jobs); err != nil {
+		log.Printf("Failed to decode expiring jobs: %v", err)
+		return
+	}
+
+	for _, job := range jobs {
+		token, err := utils.GenerateRandomToken()
+		if err != nil {
+			continue
+		}
+		tokenHash := sha256.Sum256([]byte(token))
+
+		// Claim the reminder first so a slow email never sends it twice
+		result, err := collection.UpdateOne(ctx, bson.M{
+			"_id":                job.ID,
+			"expiry_reminded_at": nil,
+		}, bson.M{
+			"$set": bson.M{
+				"expiry_reminded_at": now,
+				"extend_token_hash":  hex.EncodeToString(tokenHash[:]),
+			},
+		})
+		if err != nil || result.ModifiedCount == 0 {
+			continue
+		}
+
+		createNotification(ctx, job.PostedBy, "Job Posting Expiring",
+			"Your "+job.Title+" posting expires on "+job.ExpiresAt.Format("January 2, 2006"),
+			models.NotificationJobPosted, &job.ID, "job")
+
+		var poster models.User
+		if err := config.GetCollection("users").FindOne(ctx, bson.M{"_id": job.PostedBy}).Decode(&poster); err == nil {
+			extendURL := cfg.FrontendURL + "/jobs/extend?token=" + url.QueryEscape(token)
+			emailService.SendJobExpiryReminder(poster.Email, poster.Name, job.Title, job.Company, *job.ExpiresAt, extendURL)
+		}
+	}
+
+	if len(jobs) > 0 {
+		log.Printf("Sent expiry reminders for %d jobs", len(jobs))
+	}
+}
+
+func deactivateExpiredJobs() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	now := time.Now()
+	result, err := config.GetCollection("jobs").UpdateMany(ctx, bson.M{
+		"is_active":  true,
+		"expires_at": bson.M{"$lte": now},
+	}, bson.M{
+		"$set": bson.M{
+			"is_active":  false,
+			"expired_at": now,
+			"updated_at": now,
+		},
+	})
+	if err != nil {
+		log.Printf("Failed to deactivate expired jobs: %v", err)
+		return
+	}
+
+	if result.ModifiedCount > 0 {
+		log.Printf("Deactivated %d expired jobs", result.ModifiedCount)
+	}
+}
+
+// ExtendJob extends a job using the one-click link from its expiry reminder.
+// It needs no login; the token is single use and also revives a job that
+// has already expired.
+func (h *JobHandler) ExtendJob(c *fiber.Ctx) error {
+	var req models.ExtendJobRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid request body",
+		})
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": err.Error(),
+		})
+	}
+
+	collection := config.GetCollection("jobs")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	tokenHash := sha256.Sum256([]byte(req.Token))
+	filter := listedJobFilter()
+	filter["extend_token_hash"] = hex.EncodeToString(tokenHash[:])
+
+	var job models.Job
+	if err := collection.FindOne(ctx, filter).Decode(&job); err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid or already used extension link",
+		})
+	}
+
+	// Extend from the current expiry, or from now if it has passed
+	now := time.Now()
+	expiresAt := now
+	if job.ExpiresAt != nil && job.ExpiresAt.After(now) {
+		expiresAt = *job.ExpiresAt
+	}
+	expiresAt = expiresAt.Add(config.GetConfig().JobExtendPeriod)
+
+	err := collection.FindOneAndUpdate(ctx, bson.M{
+		"_id":               job.ID,
+		"extend_token_hash": job.ExtendTokenHash,
+	}, bson.M{
+		"$set": bson.M{
+			"is_active":  true,
+			"expires_at": expiresAt,
+			"updated_at": now,
+		},
+		"$unset": bson.M{
+			"expired_at":         "",
+			"expiry_reminded_at": "",
+			"extend_token_hash":  "",
+		},
+	}, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&job)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid or already used extension link",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"error":   false,
+		"message": "Job extended until " + expiresAt.Format("January 2, 2006"),
+		"data":    job,
+	})
+}
@@ -0,0 +1,832 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"ete-alumni-portal/config"
+	"ete-alumni-portal/middleware"
+	"ete-alumni-portal/models"
+	"ete-alumni-portal/utils"
+)
+
+type MentorshipHandler struct{}
+
+func NewMentorshipHandler() *MentorshipHandler {
+	return &MentorshipHandler{}
+}
+
+func (h *MentorshipHandler) GetMyMentorProfile(c *fiber.Ctx) error {
+	userID := middleware.GetUserID(c)
+
+	collection := config.GetCollection("mentor_profiles")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var profile models.MentorProfile
+	err := collection.FindOne(ctx, bson.M{"user_id": userID}).Decode(&profile)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "Mentor profile not found",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"error": false,
+		"data":  profile,
+	})
+}
+
+func (h *MentorshipHandler) UpsertMentorProfile(c *fiber.Ctx) error {
+	userID := middleware.GetUserID(c)
+
+	var req models.MentorProfileRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid request body",
+		})
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": err.Error(),
+		})
+	}
+
+	topics := make([]string, 0, len(req.Topics))
+	for _, topic := range req.Topics {
+		if topic = utils.SanitizeString(topic); topic != "" {
+			topics = append(topics, topic)
+		}
+	}
+
+	isAccepting := true
+	if req.IsAccepting != nil {
+		isAccepting = *req.IsAccepting
+	}
+
+	collection := config.GetCollection("mentor_profiles")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	var profile models.MentorProfile
+	err := collection.FindOneAndUpdate(
+		ctx,
+		bson.M{"user_id": userID},
+		bson.M{
+			"$set": bson.M{
+				"topics":       topics,
+				"capacity":     req.Capacity,
+				"about":        utils.SanitizeString(req.About),
+				"is_accepting": isAccepting,
+				"updated_at":   now,
+			},
+			"$setOnInsert": bson.M{
+				"user_id":        userID,
+				"active_mentees": 0,
+				"created_at":     now,
+			},
+		},
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+	).Decode(&profile)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to save mentor profile",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"error":   false,
+		"message": "Mentor profile saved successfully",
+		"data":    profile,
+	})
+}
+
+func (h *MentorshipHandler) DeleteMentorProfile(c *fiber.Ctx) error {
+	userID := middleware.GetUserID(c)
+
+	collection := config.GetCollection("mentor_profiles")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Opting out keeps existing mentorships but stops new requests
+	result, err := collection.UpdateOne(ctx, bson.M{"user_id": userID}, bson.M{
+		"$set": bson.M{
+			"is_accepting": false,
+			"updated_at":   time.Now(),
+		},
+	})
+	if err != nil || result.MatchedCount == 0 {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "Mentor profile not found",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"error":   false,
+		"message": "You are no longer accepting mentees",
+	})
+}
+
+func (h *MentorshipHandler) GetMentors(c *fiber.Ctx) error {
+	// Parse query parameters
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+	topic := c.Query("topic")
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	// Build filter
+	filter := bson.M{
+		"is_accepting": true,
+		"$expr":        bson.M{"$lt": []string{"$active_mentees", "$capacity"}},
+	}
+
+	if topic != "" {
+		filter["topics"] = bson.M{"$regex": topic, "$options": "i"}
+	}
+
+	collection := config.GetCollection("mentor_profiles")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Get total count
+	total, err := collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to count mentors",
+		})
+	}
+
+	// Get mentors with pagination
+	skip := (page - 1) * limit
+	opts := options.Find().
+		SetSkip(int64(skip)).
+		SetLimit(int64(limit)).
+		SetSort(bson.M{"updated_at": -1})
+
+	cursor, err := collection.Find(ctx, filter, opts)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to fetch mentors",
+		})
+	}
+	defer cursor.Close(ctx)
+
+	var mentors []models.MentorProfile
+	if err = cursor.All(ctx, &mentors); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to decode mentors",
+		})
+	}
+
+	// Populate mentor information
+	usersCollection := config.GetCollection("users")
+	for i := range mentors {
+		var user models.User
+		err := usersCollection.FindOne(ctx, bson.M{"_id": mentors[i].UserID}).Decode(&user)
+		if err == nil {
+			mentors[i].User = user.ToResponse()
+		}
+	}
+
+	return c.JSON(fiber.Map{
+		"error": false,
+		"data": fiber.Map{
+			"mentors": mentors,
+			"pagination": fiber.Map{
+				"page":        page,
+				"limit":       limit,
+				"total":       total,
+				"total_pages": (total + int64(limit) - 1) / int64(limit),
+			},
+		},
+	})
+}
+
+func (h *MentorshipHandler) GetMentorMatches(c *fiber.Ctx) error {
+	userID := middleware.GetUserID(c)
+	limit, _ := strconv.Atoi(c.Query("limit", "10"))
+	if limit < 1 || limit > 50 {
+		limit = 10
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	studentSkills, err := userSkillSet(ctx, userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to load your skills",
+		})
+	}
+
+	if len(studentSkills) == 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Add skills to your profile or projects to get mentor matches",
+		})
+	}
+
+	// Only mentors with free capacity are considered
+	collection := config.GetCollection("mentor_profiles")
+	cursor, err := collection.Find(ctx, bson.M{
+		"is_accepting": true,
+		"user_id":      bson.M{"$ne": userID},
+		"$expr":        bson.M{"$lt": []string{"$active_mentees", "$capacity"}},
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to fetch mentors",
+		})
+	}
+	defer cursor.Close(ctx)
+
+	var mentors []models.MentorProfile
+	if err = cursor.All(ctx, &mentors); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to decode mentors",
+		})
+	}
+
+	usersCollection := config.GetCollection("users")
+	matches := []models.MentorMatch{}
+	for i := range mentors {
+		var user models.User
+		err := usersCollection.FindOne(ctx, bson.M{
+			"_id":       mentors[i].UserID,
+			"is_active": true,
+		}).Decode(&user)
+		if err != nil {
+			continue
+		}
+		mentors[i].User = user.ToResponse()
+
+		// Mentor expertise is their declared topics plus their profile skills
+		expertise := make(map[string]bool)
+		for _, topic := range mentors[i].Topics {
+			expertise[skillKey(topic)] = true
+		}
+		for _, skill := range user.Skills {
+			expertise[skillKey(skill)] = true
+		}
+
+		matched := []string{}
+		for key, skill := range studentSkills {
+			if expertise[key] {
+				matched = append(matched, skill)
+			}
+		}
+		if len(matched) == 0 {
+			continue
+		}
+		sort.Strings(matched)
+
+		matches = append(matches, models.MentorMatch{
+			Mentor:        &mentors[i],
+			Score:         len(matched) * 100 / len(studentSkills),
+			MatchedSkills: matched,
+		})
+	}
+
+	// Highest overlap first, then mentors with more free slots
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		freeI := matches[i].Mentor.Capacity - matches[i].Mentor.ActiveMentees
+		freeJ := matches[j].Mentor.Capacity - matches[j].Mentor.ActiveMentees
+		return freeI > freeJ
+	})
+
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+
+	return c.JSON(fiber.Map{
+		"error": false,
+		"data":  matches,
+	})
+}
+
+func (h *MentorshipHandler) RequestMentorship(c *fiber.Ctx) error {
+	userID := middleware.GetUserID(c)
+
+	var req models.MentorshipRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid request body",
+		})
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": err.Error(),
+		})
+	}
+
+	mentorID, err := primitive.ObjectIDFromHex(req.MentorID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid mentor ID",
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var profile models.MentorProfile
+	err = config.GetCollection("mentor_profiles").FindOne(ctx, bson.M{
+		"user_id":      mentorID,
+		"is_accepting": true,
+	}).Decode(&profile)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "Mentor not found or not accepting mentees",
+		})
+	}
+
+	if profile.ActiveMentees >= profile.Capacity {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error":   true,
+			"message": "This mentor has no free capacity",
+		})
+	}
+
+	// Only one open mentorship per mentor and student
+	collection := config.GetCollection("mentorships")
+	var existing models.Mentorship
+	err = collection.FindOne(ctx, bson.M{
+		"mentor_id": mentorID,
+		"mentee_id": userID,
+		"status":    bson.M{"$in": []models.MentorshipStatus{models.MentorshipPending, models.MentorshipActive}},
+	}).Decode(&existing)
+	if err == nil {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error":   true,
+			"message": "You already have an open mentorship with this mentor",
+		})
+	}
+
+	now := time.Now()
+	mentorship := models.Mentorship{
+		ID:        primitive.NewObjectID(),
+		MentorID:  mentorID,
+		MenteeID:  userID,
+		Topic:     utils.SanitizeString(req.Topic),
+		Message:   utils.SanitizeString(req.Message),
+		Status:    models.MentorshipPending,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	_, err = collection.InsertOne(ctx, mentorship)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to send mentorship request",
+		})
+	}
+
+	var mentee models.User
+	config.GetCollection("users").FindOne(ctx, bson.M{"_id": userID}).Decode(&mentee)
+
+	createNotification(ctx, mentorID,
+		"New Mentorship Request",
+		mentee.Name+" has requested you as a mentor",
+		models.NotificationMentorshipReq,
+		&mentorship.ID,
+		"mentorship",
+	)
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"error":   false,
+		"message": "Mentorship request sent successfully",
+		"data":    mentorship,
+	})
+}
+
+func (h *MentorshipHandler) GetMentorships(c *fiber.Ctx) error {
+	userID := middleware.GetUserID(c)
+
+	// Parse query parameters
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+	as := c.Query("as")
+	status := c.Query("status")
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	// Build filter
+	var filter bson.M
+	switch as {
+	case "mentor":
+		filter = bson.M{"mentor_id": userID}
+	case "mentee":
+		filter = bson.M{"mentee_id": userID}
+	default:
+		filter = bson.M{
+			"$or": []bson.M{
+				{"mentor_id": userID},
+				{"mentee_id": userID},
+			},
+		}
+	}
+
+	if status != "" {
+		filter["status"] = status
+	}
+
+	collection := config.GetCollection("mentorships")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Get total count
+	total, err := collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to count mentorships",
+		})
+	}
+
+	// Get mentorships with pagination
+	skip := (page - 1) * limit
+	opts := options.Find().
+		SetSkip(int64(skip)).
+		SetLimit(int64(limit)).
+		SetSort(bson.M{"updated_at": -1})
+
+	cursor, err := collection.Find(ctx, filter, opts)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to fetch mentorships",
+		})
+	}
+	defer cursor.Close(ctx)
+
+	var mentorships []models.Mentorship
+	if err = cursor.All(ctx, &mentorships); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to decode mentorships",
+		})
+	}
+
+	// Populate mentor and mentee information
+	usersCollection := config.GetCollection("users")
+	for i := range mentorships {
+		var mentor, mentee models.User
+		if err := usersCollection.FindOne(ctx, bson.M{"_id": mentorships[i].MentorID}).Decode(&mentor); err == nil {
+			mentorships[i].Mentor = mentor.ToResponse()
+		}
+		if err := usersCollection.FindOne(ctx, bson.M{"_id": mentorships[i].MenteeID}).Decode(&mentee); err == nil {
+			mentorships[i].Mentee = mentee.ToResponse()
+		}
+	}
+
+	return c.JSON(fiber.Map{
+		"error": false,
+		"data": fiber.Map{
+			"mentorships": mentorships,
+			"pagination": fiber.Map{
+				"page":        page,
+				"limit":       limit,
+				"total":       total,
+				"total_pages": (total + int64(limit) - 1) / int64(limit),
+			},
+		},
+	})
+}
+
+func (h *MentorshipHandler) AcceptMentorship(c *fiber.Ctx) error {
+	userID := middleware.GetUserID(c)
+
+	req, err := parseMentorshipResponse(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": err.Error(),
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	mentorship, err := h.findMentorship(ctx, c, bson.M{
+		"mentor_id": userID,
+		"status":    models.MentorshipPending,
+	})
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "Mentorship not found or access denied",
+		})
+	}
+
+	// Reserve a slot atomically so capacity cannot be exceeded
+	result, err := config.GetCollection("mentor_profiles").UpdateOne(ctx, bson.M{
+		"user_id": userID,
+		"$expr":   bson.M{"$lt": []string{"$active_mentees", "$capacity"}},
+	}, bson.M{
+		"$inc": bson.M{"active_mentees": 1},
+		"$set": bson.M{"updated_at": time.Now()},
+	})
+	if err != nil || result.ModifiedCount == 0 {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error":   true,
+			"message": "You have reached your mentee capacity",
+		})
+	}
+
+	updated, err := h.changeStatus(ctx, mentorship, req, models.MentorshipActive)
+	if err != nil {
+		// Give the slot back; the request was answered concurrently or the write failed
+		config.GetCollection("mentor_profiles").UpdateOne(ctx, bson.M{
+			"user_id":        userID,
+			"active_mentees": bson.M{"$gt": 0},
+		}, bson.M{
+			"$inc": bson.M{"active_mentees": -1},
+			"$set": bson.M{"updated_at": time.Now()},
+		})
+		return mentorshipStatusError(c, err)
+	}
+
+	return h.statusChanged(c, ctx, updated, mentorship.MenteeID,
+		"Mentorship Accepted", "accepted your mentorship request")
+}
+
+func (h *MentorshipHandler) DeclineMentorship(c *fiber.Ctx) error {
+	userID := middleware.GetUserID(c)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	mentorship, err := h.findMentorship(ctx, c, bson.M{
+		"mentor_id": userID,
+		"status":    models.MentorshipPending,
+	})
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "Mentorship not found or access denied",
+		})
+	}
+
+	return h.updateStatus(c, ctx, mentorship, models.MentorshipDeclined, mentorship.MenteeID,
+		"Mentorship Declined", "declined your mentorship request")
+}
+
+func (h *MentorshipHandler) CompleteMentorship(c *fiber.Ctx) error {
+	userID := middleware.GetUserID(c)
+
+	req, err := parseMentorshipResponse(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": err.Error(),
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	mentorship, err := h.findMentorship(ctx, c, bson.M{
+		"status": models.MentorshipActive,
+		"$or": []bson.M{
+			{"mentor_id": userID},
+			{"mentee_id": userID},
+		},
+	})
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "Mentorship not found or access denied",
+		})
+	}
+
+	updated, err := h.changeStatus(ctx, mentorship, req, models.MentorshipCompleted)
+	if err != nil {
+		return mentorshipStatusError(c, err)
+	}
+
+	// Free the mentor's slot once the mentorship has actually ended
+	config.GetCollection("mentor_profiles").UpdateOne(ctx, bson.M{
+		"user_id":        mentorship.MentorID,
+		"active_mentees": bson.M{"$gt": 0},
+	}, bson.M{
+		"$inc": bson.M{"active_mentees": -1},
+		"$set": bson.M{"updated_at": time.Now()},
+	})
+
+	otherID := mentorship.MentorID
+	if otherID == userID {
+		otherID = mentorship.MenteeID
+	}
+
+	return h.statusChanged(c, ctx, updated, otherID,
+		"Mentorship Completed", "marked your mentorship as completed")
+}
+
+func (h *MentorshipHandler) CancelMentorship(c *fiber.Ctx) error {
+	userID := middleware.GetUserID(c)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	mentorship, err := h.findMentorship(ctx, c, bson.M{
+		"mentee_id": userID,
+		"status":    models.MentorshipPending,
+	})
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "Mentorship not found or access denied",
+		})
+	}
+
+	return h.updateStatus(c, ctx, mentorship, models.MentorshipCancelled, mentorship.MentorID,
+		"Mentorship Request Withdrawn", "withdrew their mentorship request")
+}
+
+// findMentorship loads the mentorship named by the :id param, restricted by filter.
+func (h *MentorshipHandler) findMentorship(ctx context.Context, c *fiber.Ctx, filter bson.M) (models.Mentorship, error) {
+	var mentorship models.Mentorship
+
+	mentorshipID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return mentorship, err
+	}
+	filter["_id"] = mentorshipID
+
+	err = config.GetCollection("mentorships").FindOne(ctx, filter).Decode(&mentorship)
+	return mentorship, err
+}
+
+// updateStatus applies a status change that needs no side effects and
+// notifies the other party
+func (h *MentorshipHandler) updateStatus(c *fiber.Ctx, ctx context.Context, mentorship models.Mentorship, status models.MentorshipStatus, notifyID primitive.ObjectID, title, action string) error {
+	req, err := parseMentorshipResponse(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": err.Error(),
+		})
+	}
+
+	updated, err := h.changeStatus(ctx, mentorship, req, status)
+	if err != nil {
+		return mentorshipStatusError(c, err)
+	}
+
+	return h.statusChanged(c, ctx, updated, notifyID, title, action)
+}
+
+// parseMentorshipResponse reads the optional note sent with a status change
+func parseMentorshipResponse(c *fiber.Ctx) (models.MentorshipResponseRequest, error) {
+	var req models.MentorshipResponseRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return req, errors.New("Invalid request body")
+		}
+	}
+	return req, utils.ValidateStruct(req)
+}
+
+// changeStatus moves the mentorship on only if it is still in the status it
+// was loaded with, so concurrent requests cannot both apply. It returns
+// mongo.ErrNoDocuments when another request got there first.
+func (h *MentorshipHandler) changeStatus(ctx context.Context, mentorship models.Mentorship, req models.MentorshipResponseRequest, status models.MentorshipStatus) (models.Mentorship, error) {
+	now := time.Now()
+	set := bson.M{
+		"status":     status,
+		"updated_at": now,
+	}
+	if req.Note != "" {
+		set["response_note"] = utils.SanitizeString(req.Note)
+	}
+	switch status {
+	case models.MentorshipActive:
+		set["accepted_at"] = now
+	case models.MentorshipCompleted:
+		set["completed_at"] = now
+	}
+
+	var updated models.Mentorship
+	err := config.GetCollection("mentorships").FindOneAndUpdate(
+		ctx,
+		bson.M{"_id": mentorship.ID, "status": mentorship.Status},
+		bson.M{"$set": set},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&updated)
+	return updated, err
+}
+
+func mentorshipStatusError(c *fiber.Ctx, err error) error {
+	if err == mongo.ErrNoDocuments {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error":   true,
+			"message": "Mentorship has already been updated",
+		})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"error":   true,
+		"message": "Failed to update mentorship",
+	})
+}
+
+// statusChanged notifies the other party and returns the updated mentorship
+func (h *MentorshipHandler) statusChanged(c *fiber.Ctx, ctx context.Context, updated models.Mentorship, notifyID primitive.ObjectID, title, action string) error {
+	var actor models.User
+	config.GetCollection("users").FindOne(ctx, bson.M{"_id": middleware.GetUserID(c)}).Decode(&actor)
+
+	createNotification(ctx, notifyID,
+		title,
+		actor.Name+" "+action,
+		models.NotificationMentorshipUpdate,
+		&updated.ID,
+		"mentorship",
+	)
+
+	return c.JSON(fiber.Map{
+		"error":   false,
+		"message": "Mentorship updated successfully",
+		"data":    updated,
+	})
+}
+
+// userSkillSet collects a user's profile skills and the technologies of the
+// projects they authored, keyed by normalized skill name.
+func userSkillSet(ctx context.Context, userID primitive.ObjectID) (map[string]string, error) {
+	skills := make(map[string]string)
+
+	var user models.User
+	if err := config.GetCollection("users").FindOne(ctx, bson.M{"_id": userID}).Decode(&user); err != nil {
+		return nil, err
+	}
+	for _, skill := range user.Skills {
+		if key := skillKey(skill); key != "" {
+			skills[key] = skill
+		}
+	}
+
+	cursor, err := config.GetCollection("projects").Find(ctx, bson.M{
+		"is_active": true,
+		"$and":      []bson.M{projectMemberFilter(userID)},
+	}, options.Find().SetProjection(bson.M{"technologies": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var projects []models.Project
+	if err = cursor.All(ctx, &projects); err != nil {
+		return nil, err
+	}
+	for _, project := range projects {
+		for _, technology := range project.Technologies {
+			key := skillKey(technology)
+			if _, ok := skills[key]; !ok && key != "" {
+				skills[key] = technology
+			}
+		}
+	}
+
+	return skills, nil
+}
@@ -190,6 +190,23 @@ func (h *MessageHandler) SendMessage(c *fiber.Ctx) error {
 		})
 	}
 
+	// Respect the recipient's messaging permissions. Users who only accept
+	// messages from connections can still be replied to.
+	if recipient.Privacy.AllowMessagesFrom == models.VisibilityConnections &&
+		middleware.GetUserRole(c) != models.RoleAdmin &&
+		!areConnected(ctx, userID, recipientID) {
+		previous, _ := config.GetCollection("messages").CountDocuments(ctx, bson.M{
+			"sender_id":    recipientID,
+			"recipient_id": userID,
+		})
+		if previous == 0 {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error":   true,
+				"message": "This user only accepts messages from connections",
+			})
+		}
+	}
+
 	// Get sender info for notification
 	var sender models.User
 	err = usersCollection.FindOne(ctx, bson.M{"_id": userID}).Decode(&sender)
@@ -152,3 +152,22 @@ func (h *NotificationHandler) MarkAllAsRead(c *fiber.Ctx) error {
 		"message": "All notifications marked as read",
 	})
 }
+
+// createNotification stores an in-app notification for a single user.
+// Failures are ignored so that notifications never block the main action.
+func createNotification(ctx context.Context, userID primitive.ObjectID, title, message string, notificationType models.NotificationType, relatedID *primitive.ObjectID, relatedType string) {
+	notification := models.Notification{
+		ID:               primitive.NewObjectID(),
+		UserID:           userID,
+		Title:            title,
+		Message:          message,
+		NotificationType: notificationType,
+		RelatedID:        relatedID,
+		RelatedType:      relatedType,
+		IsRead:           false,
+		CreatedAt:        time.Now(),
+	}
+
+	notificationsCollection := config.GetCollection("notifications")
+	_, _ = notificationsCollection.InsertOne(ctx, notification)
+}
@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"ete-alumni-portal/config"
+	"ete-alumni-portal/middleware"
+	"ete-alumni-portal/models"
+)
+
+// recordProfileView logs a view, keeping at most one entry per viewer per day
+func recordProfileView(ctx context.Context, viewerID, viewedID primitive.ObjectID) {
+	now := time.Now()
+	config.GetCollection("profile_views").UpdateOne(ctx, bson.M{
+		"viewer_id": viewerID,
+		"viewed_id": viewedID,
+		"day":       now.UTC().Format("2006-01-02"),
+	}, bson.M{
+		"$set":         bson.M{"last_viewed_at": now},
+		"$setOnInsert": bson.M{"first_viewed_at": now},
+	}, options.Update().SetUpsert(true))
+}
+
+// GetProfileViews returns daily view counts and recent viewers for the current user
+func (h *UserHandler) GetProfileViews(c *fiber.Ctx) error {
+	userID := middleware.GetUserID(c)
+	days, _ := strconv.Atoi(c.Query("days", "30"))
+	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+
+	if days < 1 || days > 365 {
+		days = 30
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	collection := config.GetCollection("profile_views")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	since := time.Now().UTC().AddDate(0, 0, -(days - 1)).Format("2006-01-02")
+	filter := bson.M{
+		"viewed_id": userID,
+		"day":       bson.M{"$gte": since},
+	}
+
+	// Views per day
+	cursor, err := collection.Aggregate(ctx, bson.A{
+		bson.M{"$match": filter},
+		bson.M{"$group": bson.M{"_id": "$day", "views": bson.M{"$sum": 1}}},
+		bson.M{"$sort": bson.M{"_id": 1}},
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to fetch profile views",
+		})
+	}
+	defer cursor.Close(ctx)
+
+	daily := []models.ProfileViewDay{}
+	if err = cursor.All(ctx, &daily); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to decode profile views",
+		})
+	}
+
+	totalViews := 0
+	for _, day := range daily {
+		totalViews += day.Views
+	}
+
+	viewerIDs, err := collection.Distinct(ctx, "viewer_id", filter)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to count viewers",
+		})
+	}
+
+	// Most recent view per viewer
+	cursor, err = collection.Aggregate(ctx, bson.A{
+		bson.M{"$match": filter},
+		bson.M{"$group": bson.M{"_id": "$viewer_id", "last_viewed_at": bson.M{"$max": "$last_viewed_at"}}},
+		bson.M{"$sort": bson.M{"last_viewed_at": -1}},
+		bson.M{"$limit": limit},
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to fetch recent viewers",
+		})
+	}
+	defer cursor.Close(ctx)
+
+	var recent []struct {
+		ViewerID     primitive.ObjectID `bson:"_id"`
+		LastViewedAt time.Time          `bson:"last_viewed_at"`
+	}
+	if err = cursor.All(ctx, &recent); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to decode recent viewers",
+		})
+	}
+
+	// Populate viewers, respecting their privacy settings
+	connected, _ := connectedUserIDs(ctx, userID)
+	usersCollection := config.GetCollection("users")
+	viewers := []models.ProfileViewer{}
+	for _, view := range recent {
+		viewer := models.ProfileViewer{Anonymous: true, ViewedAt: view.LastViewedAt}
+
+		var user models.User
+		err := usersCollection.FindOne(ctx, bson.M{
+			"_id":       view.ViewerID,
+			"is_active": true,
+		}).Decode(&user)
+		if err == nil && !user.Privacy.ViewsHidden() {
+			if response := user.ToResponseFor(userID, connected[user.ID]); !response.ProfileRestricted {
+				viewer.Viewer = response
+				viewer.Anonymous = false
+			}
+		}
+
+		viewers = append(viewers, viewer)
+	}
+
+	return c.JSON(fiber.Map{
+		"error": false,
+		"data": fiber.Map{
+			"days":           days,
+			"total_views":    totalViews,
+			"unique_viewers": len(viewerIDs),
+			"daily":          daily,
+			"recent_viewers": viewers,
+		},
+	})
+}
@@ -23,12 +23,20 @@ func NewProjectHandler() *ProjectHandler {
 }
 
 func (h *ProjectHandler) GetProjects(c *fiber.Ctx) error {
+	userID := middleware.GetUserID(c)
+	userRole := middleware.GetUserRole(c)
+
 	// Parse query parameters
 	page, _ := strconv.Atoi(c.Query("page", "1"))
 	limit, _ := strconv.Atoi(c.Query("limit", "20"))
 	projectType := c.Query("type")
 	search := c.Query("search")
 	authorID := c.Query("author_id")
+	mine := c.Query("mine") == "true"
+	status := c.Query("status")
+	sortBy := c.Query("sort")
+	period := c.Query("period", "week")
+	featured := c.Query("featured") == "true"
 
 	if page < 1 {
 		page = 1
@@ -44,12 +52,37 @@ func (h *ProjectHandler) GetProjects(c *fiber.Ctx) error {
 		filter["project_type"] = projectType
 	}
 
+	if featured {
+		filter["is_featured"] = true
+	}
+
+	and := []bson.M{}
 	if authorID != "" {
+		// Team projects are listed for every accepted contributor too
 		if objID, err := primitive.ObjectIDFromHex(authorID); err == nil {
-			filter["author_id"] = objID
+			and = append(and, projectMemberFilter(objID))
 		}
 	}
 
+	// Only approved projects are public. Members see their own projects in
+	// any state, and faculty and admins can filter the review pipeline.
+	canFilterStatus := mine || userRole == models.RoleFaculty || userRole == models.RoleAdmin
+	if mine {
+		and = append(and, projectMemberFilter(userID))
+	}
+	if status != "" && canFilterStatus {
+		filter["status"] = status
+		if models.ProjectStatus(status) == models.ProjectApproved {
+			filter["status"] = approvedProjectStatus()
+		}
+	} else if !mine {
+		filter["status"] = approvedProjectStatus()
+	}
+
+	if len(and) > 0 {
+		filter["$and"] = and
+	}
+
 	if search != "" {
 		filter["$or"] = []bson.M{
 			{"title": bson.M{"$regex": search, "$options": "i"}},
@@ -76,7 +109,7 @@ func (h *ProjectHandler) GetProjects(c *fiber.Ctx) error {
 	opts := options.Find().
 		SetSkip(int64(skip)).
 		SetLimit(int64(limit)).
-		SetSort(bson.M{"created_at": -1})
+		SetSort(projectSortOrder(sortBy, period))
 
 	cursor, err := collection.Find(ctx, filter, opts)
 	if err != nil {
@@ -103,6 +136,7 @@ func (h *ProjectHandler) GetProjects(c *fiber.Ctx) error {
 		if err == nil {
 			projects[i].Author = user.ToResponse()
 		}
+		populateProjectContributors(ctx, &projects[i], true)
 	}
 
 	return c.JSON(fiber.Map{
@@ -138,19 +172,22 @@ func (h *ProjectHandler) CreateProject(c *fiber.Ctx) error {
 	}
 
 	project := models.Project{
-		ID:           primitive.NewObjectID(),
-		Title:        utils.SanitizeString(req.Title),
-		Description:  utils.SanitizeString(req.Description),
-		ProjectType:  req.ProjectType,
-		Technologies: req.Technologies,
-		GitHubURL:    req.GitHubURL,
-		DemoURL:      req.DemoURL,
-		AuthorID:     userID,
-		LikesCount:   0,
-		ViewsCount:   0,
-		IsActive:     true,
-		CreatedAt:    time.Now(),
-		UpdatedAt:    time.Now(),
+		ID:               primitive.NewObjectID(),
+		Title:            utils.SanitizeString(req.Title),
+		Description:      utils.SanitizeString(req.Description),
+		ProjectType:      req.ProjectType,
+		Technologies:     req.Technologies,
+		GitHubURL:        req.GitHubURL,
+		DemoURL:          req.DemoURL,
+		AuthorID:         userID,
+		SyncTechnologies: req.SyncTechnologies,
+		Status:           models.ProjectDraft,
+		Version:          1,
+		LikesCount:       0,
+		ViewsCount:       0,
+		IsActive:         true,
+		CreatedAt:        time.Now(),
+		UpdatedAt:        time.Now(),
 	}
 
 	collection := config.GetCollection("projects")
@@ -165,6 +202,19 @@ func (h *ProjectHandler) CreateProject(c *fiber.Ctx) error {
 		})
 	}
 
+	config.GetCollection("project_versions").InsertOne(ctx, models.ProjectVersion{
+		ID:        primitive.NewObjectID(),
+		ProjectID: project.ID,
+		Version:   1,
+		Snapshot:  project.Snapshot(),
+		EditedBy:  userID,
+		CreatedAt: project.CreatedAt,
+	})
+
+	if project.GitHubURL != "" {
+		queueRepoMetadataRefresh(project.ID)
+	}
+
 	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
 		"error":   false,
 		"message": "Project created successfully",
@@ -191,17 +241,15 @@ func (h *ProjectHandler) GetProjectByID(c *fiber.Ctx) error {
 		"_id":       projectID,
 		"is_active": true,
 	}).Decode(&project)
-	if err != nil {
+	if err != nil || !canViewProject(&project, middleware.GetUserID(c), middleware.GetUserRole(c)) {
 		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
 			"error":   true,
 			"message": "Project not found",
 		})
 	}
 
-	// Increment view count
-	collection.UpdateOne(ctx, bson.M{"_id": projectID}, bson.M{
-		"$inc": bson.M{"views_count": 1},
-	})
+	// Count the view; self-views, bots and repeat views are ignored
+	recordProjectView(ctx, &project, middleware.GetUserID(c), c.Get(fiber.HeaderUserAgent))
 
 	// Populate author information
 	userCollection := config.GetCollection("users")
@@ -210,6 +258,14 @@ func (h *ProjectHandler) GetProjectByID(c *fiber.Ctx) error {
 	if err == nil {
 		project.Author = user.ToResponse()
 	}
+	populateProjectContributors(ctx, &project, false)
+	project.Media = loadProjectMedia(ctx, projectID)
+	if project.ReviewerID != nil {
+		var reviewer models.User
+		if err := userCollection.FindOne(ctx, bson.M{"_id": *project.ReviewerID}).Decode(&reviewer); err == nil {
+			project.Reviewer = reviewer.ToResponse()
+		}
+	}
 
 	return c.JSON(fiber.Map{
 		"error": false,
@@ -247,12 +303,12 @@ func (h *ProjectHandler) UpdateProject(c *fiber.Ctx) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	// Check if project exists and user owns it
+	// Check if project exists and user owns it or is an accepted contributor
 	var existingProject models.Project
 	err = collection.FindOne(ctx, bson.M{
 		"_id":       projectID,
-		"author_id": userID,
 		"is_active": true,
+		"$and":      []bson.M{projectMemberFilter(userID)},
 	}).Decode(&existingProject)
 	if err != nil {
 		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
@@ -286,6 +342,9 @@ func (h *ProjectHandler) UpdateProject(c *fiber.Ctx) error {
 	if req.DemoURL != "" {
 		update["$set"].(bson.M)["demo_url"] = req.DemoURL
 	}
+	if req.SyncTechnologies != nil {
+		update["$set"].(bson.M)["sync_technologies"] = *req.SyncTechnologies
+	}
 
 	var project models.Project
 	err = collection.FindOneAndUpdate(
@@ -301,6 +360,17 @@ func (h *ProjectHandler) UpdateProject(c *fiber.Ctx) error {
 		})
 	}
 
+	project.Version = recordProjectVersion(ctx, &existingProject, &project, userID, nil)
+	if project.Version != existingProject.Version {
+		project.Status = resubmitEditedProject(ctx, &existingProject, userID)
+	}
+
+	// Fetch metadata for a new repository, or to sync technologies
+	syncEnabled := req.SyncTechnologies != nil && *req.SyncTechnologies && !existingProject.SyncTechnologies
+	if project.GitHubURL != "" && (project.GitHubURL != existingProject.GitHubURL || syncEnabled) {
+		queueRepoMetadataRefresh(project.ID)
+	}
+
 	return c.JSON(fiber.Map{
 		"error":   false,
 		"message": "Project updated successfully",
@@ -334,19 +404,23 @@ func (h *ProjectHandler) DeleteProject(c *fiber.Ctx) error {
 		filter["author_id"] = userID
 	}
 
-	_, err = collection.UpdateOne(ctx, filter, bson.M{
+	result, err := collection.UpdateOne(ctx, filter, bson.M{
 		"$set": bson.M{
 			"is_active":  false,
 			"updated_at": time.Now(),
 		},
+		"$unset": bson.M{"cover_image_url": ""},
 	})
-	if err != nil {
+	if err != nil || result.MatchedCount == 0 {
 		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
 			"error":   true,
 			"message": "Project not found or access denied",
 		})
 	}
 
+	// Attachments are removed with the project
+	deleteProjectMedia(ctx, projectID)
+
 	return c.JSON(fiber.Map{
 		"error":   false,
 		"message": "Project deleted successfully",
@@ -368,6 +442,19 @@ func (h *ProjectHandler) LikeProject(c *fiber.Ctx) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
+	// Drafts and projects under review cannot be liked by outsiders
+	var project models.Project
+	err = config.GetCollection("projects").FindOne(ctx, bson.M{
+		"_id":       projectID,
+		"is_active": true,
+	}).Decode(&project)
+	if err != nil || !canViewProject(&project, userID, middleware.GetUserRole(c)) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "Project not found",
+		})
+	}
+
 	// Check if already liked
 	var existingLike models.ProjectLike
 	err = likesCollection.FindOne(ctx, bson.M{
@@ -0,0 +1,834 @@
+package handlers
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"ete-alumni-portal/config"
+	"ete-alumni-portal/middleware"
+	"ete-alumni-portal/models"
+	"ete-alumni-portal/utils"
+)
+
+// GetComments lists top-level comments on a project, or the replies to a
+// comment when parent_id is given.
+func (h *ProjectHandler) GetComments(c *fiber.Ctx) error {
+	userID := middleware.GetUserID(c)
+	userRole := middleware.GetUserRole(c)
+	projectID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid project ID",
+		})
+	}
+
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+	parentID := c.Query("parent_id")
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	filter := bson.M{"project_id": projectID}
+	sort := bson.M{"created_at": -1}
+
+	if parentID != "" {
+		objID, err := primitive.ObjectIDFromHex(parentID)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   true,
+				"message": "Invalid parent comment ID",
+			})
+		}
+		filter["parent_id"] = objID
+		// Replies read top to bottom
+		sort = bson.M{"created_at": 1}
+	} else {
+		filter["parent_id"] = bson.M{"$exists": false}
+	}
+
+	collection := config.GetCollection("project_comments")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var project models.Project
+	err = config.GetCollection("projects").FindOne(ctx, bson.M{
+		"_id":       projectID,
+		"is_active": true,
+	}).Decode(&project)
+	if err != nil || !canViewProject(&project, userID, userRole) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "Project not found",
+		})
+	}
+
+	// Hidden comments are only visible to admins and the project owner, who
+	// can restore them; deleted ones stay as placeholders so their replies
+	// keep their place in the thread
+	if userRole != models.RoleAdmin && project.AuthorID != userID {
+		filter["status"] = bson.M{"$ne": models.CommentHidden}
+	}
+
+	total, err := collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to count comments",
+		})
+	}
+
+	skip := (page - 1) * limit
+	opts := options.Find().
+		SetSkip(int64(skip)).
+		SetLimit(int64(limit)).
+		SetSort(sort)
+
+	cursor, err := collection.Find(ctx, filter, opts)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to fetch comments",
+		})
+	}
+	defer cursor.Close(ctx)
+
+	comments := []models.ProjectComment{}
+	if err = cursor.All(ctx, &comments); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to decode comments",
+		})
+	}
+
+	populateCommentAuthors(ctx, comments)
+
+	return c.JSON(fiber.Map{
+		"error": false,
+		"data": fiber.Map{
+			"comments": comments,
+			"pagination": fiber.Map{
+				"page":        page,
+				"limit":       limit,
+				"total":       total,
+				"total_pages": (total + int64(limit) - 1) / int64(limit),
+			},
+		},
+	})
+}
+
+func (h *ProjectHandler) CreateComment(c *fiber.Ctx) error {
+	userID := middleware.GetUserID(c)
+	projectID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid project ID",
+		})
+	}
+
+	var req models.CreateCommentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid request body",
+		})
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": err.Error(),
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var project models.Project
+	err = config.GetCollection("projects").FindOne(ctx, bson.M{
+		"_id":       projectID,
+		"is_active": true,
+	}).Decode(&project)
+	if err != nil || !canViewProject(&project, userID, middleware.GetUserRole(c)) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "Project not found",
+		})
+	}
+
+	collection := config.GetCollection("project_comments")
+
+	var parent *models.ProjectComment
+	if req.ParentID != "" {
+		parentID, err := primitive.ObjectIDFromHex(req.ParentID)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   true,
+				"message": "Invalid parent comment ID",
+			})
+		}
+
+		parent = &models.ProjectComment{}
+		err = collection.FindOne(ctx, bson.M{
+			"_id":        parentID,
+			"project_id": projectID,
+			"status":     models.CommentVisible,
+		}).Decode(parent)
+		if err != nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error":   true,
+				"message": "Parent comment not found",
+			})
+		}
+	}
+
+	comment := models.ProjectComment{
+		ID:         primitive.NewObjectID(),
+		ProjectID:  projectID,
+		AuthorID:   userID,
+		Content:    utils.SanitizeString(req.Content),
+		MentionIDs: resolveMentions(ctx, req.MentionIDs, userID),
+		Status:     models.CommentVisible,
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
+	}
+	if parent != nil {
+		comment.ParentID = &parent.ID
+	}
+
+	_, err = collection.InsertOne(ctx, comment)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to create comment",
+		})
+	}
+
+	config.GetCollection("projects").UpdateOne(ctx, bson.M{"_id": projectID}, bson.M{
+		"$inc": bson.M{"comments_count": 1},
+	})
+	if parent != nil {
+		collection.UpdateOne(ctx, bson.M{"_id": parent.ID}, bson.M{
+			"$inc": bson.M{"replies_count": 1},
+		})
+	}
+
+	var author models.User
+	config.GetCollection("users").FindOne(ctx, bson.M{"_id": userID}).Decode(&author)
+	comment.Author = author.ToResponse()
+
+	// Notify everyone involved once: mentioned users, the parent comment's
+	// author and the project team
+	notified := map[primitive.ObjectID]bool{userID: true}
+	for _, mentionID := range comment.MentionIDs {
+		notified[mentionID] = true
+		createNotification(ctx, mentionID,
+			"You were mentioned",
+			author.Name+" mentioned you in a comment on "+project.Title,
+			models.NotificationMentioned,
+			&project.ID,
+			"project",
+		)
+	}
+	if parent != nil && !notified[parent.AuthorID] {
+		notified[parent.AuthorID] = true
+		createNotification(ctx, parent.AuthorID,
+			"New Reply",
+			author.Name+" replied to your comment on "+project.Title,
+			models.NotificationProjectComment,
+			&project.ID,
+			"project",
+		)
+	}
+	team := []primitive.ObjectID{project.AuthorID}
+	for _, contributor := range project.Contributors {
+		if contributor.Status == models.ContributorAccepted {
+			team = append(team, contributor.UserID)
+		}
+	}
+	for _, memberID := range team {
+		if notified[memberID] {
+			continue
+		}
+		notified[memberID] = true
+		createNotification(ctx, memberID,
+			"New Comment",
+			author.Name+" commented on "+project.Title,
+			models.NotificationProjectComment,
+			&project.ID,
+			"project",
+		)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"error":   false,
+		"message": "Comment added successfully",
+		"data":    comment,
+	})
+}
+
+func (h *ProjectHandler) UpdateComment(c *fiber.Ctx) error {
+	userID := middleware.GetUserID(c)
+	projectID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid project ID",
+		})
+	}
+
+	commentID, err := primitive.ObjectIDFromHex(c.Params("commentId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid comment ID",
+		})
+	}
+
+	var req models.UpdateCommentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid request body",
+		})
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": err.Error(),
+		})
+	}
+
+	collection := config.GetCollection("project_comments")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Only the author can edit, and only while the comment is visible
+	filter := bson.M{
+		"_id":        commentID,
+		"project_id": projectID,
+		"author_id":  userID,
+		"status":     models.CommentVisible,
+	}
+
+	var existing models.ProjectComment
+	if err := collection.FindOne(ctx, filter).Decode(&existing); err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "Comment not found or access denied",
+		})
+	}
+
+	mentionIDs := resolveMentions(ctx, req.MentionIDs, userID)
+
+	var comment models.ProjectComment
+	err = collection.FindOneAndUpdate(ctx, filter, bson.M{
+		"$set": bson.M{
+			"content":     utils.SanitizeString(req.Content),
+			"mention_ids": mentionIDs,
+			"is_edited":   true,
+			"updated_at":  time.Now(),
+		},
+	}, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&comment)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to update comment",
+		})
+	}
+
+	// Only newly mentioned users are notified
+	alreadyMentioned := map[primitive.ObjectID]bool{}
+	for _, id := range existing.MentionIDs {
+		alreadyMentioned[id] = true
+	}
+
+	var author models.User
+	config.GetCollection("users").FindOne(ctx, bson.M{"_id": userID}).Decode(&author)
+	comment.Author = author.ToResponse()
+
+	for _, mentionID := range mentionIDs {
+		if alreadyMentioned[mentionID] {
+			continue
+		}
+		createNotification(ctx, mentionID,
+			"You were mentioned",
+			author.Name+" mentioned you in a comment",
+			models.NotificationMentioned,
+			&comment.ProjectID,
+			"project",
+		)
+	}
+
+	return c.JSON(fiber.Map{
+		"error":   false,
+		"message": "Comment updated successfully",
+		"data":    comment,
+	})
+}
+
+// DeleteComment removes a comment's content. The comment's author, the
+// project owner and admins can delete; the entry stays as a placeholder so
+// replies remain threaded.
+func (h *ProjectHandler) DeleteComment(c *fiber.Ctx) error {
+	userID := middleware.GetUserID(c)
+	userRole := middleware.GetUserRole(c)
+	projectID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid project ID",
+		})
+	}
+
+	commentID, err := primitive.ObjectIDFromHex(c.Params("commentId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid comment ID",
+		})
+	}
+
+	collection := config.GetCollection("project_comments")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{
+		"_id":        commentID,
+		"project_id": projectID,
+		"status":     bson.M{"$ne": models.CommentDeleted},
+	}
+
+	if userRole != models.RoleAdmin && !isProjectOwner(ctx, projectID, userID) {
+		filter["author_id"] = userID
+	}
+
+	var comment models.ProjectComment
+	err = collection.FindOneAndUpdate(ctx, filter, bson.M{
+		"$set": bson.M{
+			"status":     models.CommentDeleted,
+			"content":    "",
+			"updated_at": time.Now(),
+		},
+		"$unset": bson.M{"mention_ids": ""},
+	}).Decode(&comment)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "Comment not found or access denied",
+		})
+	}
+
+	config.GetCollection("projects").UpdateOne(ctx, bson.M{"_id": projectID}, bson.M{
+		"$inc": bson.M{"comments_count": -1},
+	})
+	if comment.ParentID != nil {
+		collection.UpdateOne(ctx, bson.M{"_id": *comment.ParentID}, bson.M{
+			"$inc": bson.M{"replies_count": -1},
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"error":   false,
+		"message": "Comment deleted successfully",
+	})
+}
+
+// ReportComment flags a comment for moderation. Comments reported by enough
+// users are hidden until a moderator restores them.
+func (h *ProjectHandler) ReportComment(c *fiber.Ctx) error {
+	userID := middleware.GetUserID(c)
+	projectID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid project ID",
+		})
+	}
+
+	commentID, err := primitive.ObjectIDFromHex(c.Params("commentId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid comment ID",
+		})
+	}
+
+	var req models.ReportCommentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid request body",
+		})
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": err.Error(),
+		})
+	}
+
+	collection := config.GetCollection("project_comments")
+	reportsCollection := config.GetCollection("comment_reports")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var comment models.ProjectComment
+	err = collection.FindOne(ctx, bson.M{
+		"_id":        commentID,
+		"project_id": projectID,
+		"status":     models.CommentVisible,
+	}).Decode(&comment)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "Comment not found",
+		})
+	}
+
+	if comment.AuthorID == userID {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "You cannot report your own comment",
+		})
+	}
+
+	count, _ := reportsCollection.CountDocuments(ctx, bson.M{
+		"comment_id":  commentID,
+		"reporter_id": userID,
+		"resolved_at": bson.M{"$exists": false},
+	})
+	if count > 0 {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error":   true,
+			"message": "You have already reported this comment",
+		})
+	}
+
+	report := models.CommentReport{
+		ID:         primitive.NewObjectID(),
+		CommentID:  commentID,
+		ProjectID:  projectID,
+		ReporterID: userID,
+		Reason:     req.Reason,
+		Details:    utils.SanitizeString(req.Details),
+		CreatedAt:  time.Now(),
+	}
+
+	_, err = reportsCollection.InsertOne(ctx, report)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to report comment",
+		})
+	}
+
+	err = collection.FindOneAndUpdate(ctx, bson.M{"_id": commentID}, bson.M{
+		"$inc": bson.M{"reports_count": 1},
+	}, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&comment)
+	if err == nil && comment.ReportsCount >= models.CommentReportThreshold && comment.Status == models.CommentVisible {
+		collection.UpdateOne(ctx, bson.M{"_id": commentID, "status": models.CommentVisible}, bson.M{
+			"$set": bson.M{
+				"status":     models.CommentHidden,
+				"hidden_by":  models.HiddenByReports,
+				"updated_at": time.Now(),
+			},
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"error":   false,
+		"message": "Comment reported successfully",
+	})
+}
+
+// ModerateComment hides or restores a comment. Available to admins and the
+// project owner, though the owner can only restore comments they hid
+// themselves. An admin restoring a comment resolves its reports.
+func (h *ProjectHandler) ModerateComment(c *fiber.Ctx) error {
+	userID := middleware.GetUserID(c)
+	userRole := middleware.GetUserRole(c)
+	projectID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid project ID",
+		})
+	}
+
+	commentID, err := primitive.ObjectIDFromHex(c.Params("commentId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid comment ID",
+		})
+	}
+
+	var req models.ModerateCommentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid request body",
+		})
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": err.Error(),
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if userRole != models.RoleAdmin && !isProjectOwner(ctx, projectID, userID) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error":   true,
+			"message": "Only the project owner or an admin can moderate comments",
+		})
+	}
+
+	collection := config.GetCollection("project_comments")
+	filter := bson.M{
+		"_id":        commentID,
+		"project_id": projectID,
+		"status":     bson.M{"$ne": models.CommentDeleted},
+	}
+
+	var comment models.ProjectComment
+	if err := collection.FindOne(ctx, filter).Decode(&comment); err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "Comment not found",
+		})
+	}
+
+	now := time.Now()
+	var update bson.M
+	if req.Action == "restore" {
+		// Comments hidden by an admin or by reports wait for an admin
+		if userRole != models.RoleAdmin && comment.HiddenBy != models.HiddenByOwner {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error":   true,
+				"message": "Only an admin can restore this comment",
+			})
+		}
+		filter["hidden_by"] = comment.HiddenBy
+
+		set := bson.M{
+			"status":       models.CommentVisible,
+			"moderated_by": userID,
+			"updated_at":   now,
+		}
+		if userRole == models.RoleAdmin {
+			set["reports_count"] = 0
+		}
+		update = bson.M{"$set": set, "$unset": bson.M{"hidden_by": ""}}
+	} else {
+		hiddenBy := models.HiddenByOwner
+		if userRole == models.RoleAdmin {
+			hiddenBy = models.HiddenByAdmin
+		} else {
+			// The owner can't take over a comment hidden by an admin or by reports
+			filter["status"] = models.CommentVisible
+		}
+		update = bson.M{"$set": bson.M{
+			"status":       models.CommentHidden,
+			"hidden_by":    hiddenBy,
+			"moderated_by": userID,
+			"updated_at":   now,
+		}}
+	}
+
+	result, err := collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to moderate comment",
+		})
+	}
+	if result.MatchedCount == 0 {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error":   true,
+			"message": "Comment has already been moderated",
+		})
+	}
+
+	// Reports are kept as a record; an admin's restore resolves them
+	if req.Action == "restore" && userRole == models.RoleAdmin {
+		config.GetCollection("comment_reports").UpdateMany(ctx, bson.M{
+			"comment_id":  commentID,
+			"resolved_at": bson.M{"$exists": false},
+		}, bson.M{
+			"$set": bson.M{"resolved_by": userID, "resolved_at": now},
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"error":   false,
+		"message": "Comment moderated successfully",
+	})
+}
+
+// GetReportedComments lists reported comments for admin review
+func (h *ProjectHandler) GetReportedComments(c *fiber.Ctx) error {
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	collection := config.GetCollection("project_comments")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{
+		"reports_count": bson.M{"$gt": 0},
+		"status":        bson.M{"$ne": models.CommentDeleted},
+	}
+
+	total, err := collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to count reported comments",
+		})
+	}
+
+	skip := (page - 1) * limit
+	opts := options.Find().
+		SetSkip(int64(skip)).
+		SetLimit(int64(limit)).
+		SetSort(bson.D{{Key: "reports_count", Value: -1}, {Key: "updated_at", Value: -1}})
+
+	cursor, err := collection.Find(ctx, filter, opts)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to fetch reported comments",
+		})
+	}
+	defer cursor.Close(ctx)
+
+	comments := []models.ProjectComment{}
+	if err = cursor.All(ctx, &comments); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to decode reported comments",
+		})
+	}
+
+	populateCommentAuthors(ctx, comments)
+
+	reportsCollection := config.GetCollection("comment_reports")
+	reported := []models.ReportedComment{}
+	for _, comment := range comments {
+		item := models.ReportedComment{Comment: comment, Reports: []models.CommentReport{}}
+		cursor, err := reportsCollection.Find(ctx, bson.M{
+			"comment_id":  comment.ID,
+			"resolved_at": bson.M{"$exists": false},
+		},
+			options.Find().SetSort(bson.M{"created_at": -1}))
+		if err == nil {
+			cursor.All(ctx, &item.Reports)
+			cursor.Close(ctx)
+		}
+		reported = append(reported, item)
+	}
+
+	return c.JSON(fiber.Map{
+		"error": false,
+		"data": fiber.Map{
+			"comments": reported,
+			"pagination": fiber.Map{
+				"page":        page,
+				"limit":       limit,
+				"total":       total,
+				"total_pages": (total + int64(limit) - 1) / int64(limit),
+			},
+		},
+	})
+}
+
+// isProjectOwner reports whether the user authored the project
+func isProjectOwner(ctx context.Context, projectID, userID primitive.ObjectID) bool {
+	count, _ := config.GetCollection("projects").CountDocuments(ctx, bson.M{
+		"_id":       projectID,
+		"author_id": userID,
+	})
+	return count > 0
+}
+
+// resolveMentions keeps the mentioned IDs that belong to active users
+func resolveMentions(ctx context.Context, ids []string, authorID primitive.ObjectID) []primitive.ObjectID {
+	candidates := []primitive.ObjectID{}
+	seen := map[primitive.ObjectID]bool{authorID: true}
+	for _, id := range ids {
+		objID, err := primitive.ObjectIDFromHex(id)
+		if err != nil || seen[objID] {
+			continue
+		}
+		seen[objID] = true
+		candidates = append(candidates, objID)
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	values, err := config.GetCollection("users").Distinct(ctx, "_id", bson.M{
+		"_id":         bson.M{"$in": candidates},
+		"is_verified": true,
+		"is_active":   true,
+	})
+	if err != nil {
+		return nil
+	}
+
+	mentions := []primitive.ObjectID{}
+	for _, value := range values {
+		if id, ok := value.(primitive.ObjectID); ok {
+			mentions = append(mentions, id)
+		}
+	}
+	return mentions
+}
+
+func populateCommentAuthors(ctx context.Context, comments []models.ProjectComment) {
+	usersCollection := config.GetCollection("users")
+	authors := map[primitive.ObjectID]*models.UserResponse{}
+	for i := range comments {
+		// Deleted comments don't reveal who wrote them
+		if comments[i].Status == models.CommentDeleted {
+			comments[i].AuthorID = primitive.NilObjectID
+			continue
+		}
+		author, ok := authors[comments[i].AuthorID]
+		if !ok {
+			var user models.User
+			if err := usersCollection.FindOne(ctx, bson.M{"_id": comments[i].AuthorID}).Decode(&user); err == nil {
+				author = user.ToResponse()
+			}
+			authors[comments[i].AuthorID] = author
+		}
+		comments[i].Author = author
+	}
+}
@@ -0,0 +1,412 @@
+package handlers
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"ete-alumni-portal/config"
+	"ete-alumni-portal/middleware"
+	"ete-alumni-portal/models"
+	"ete-alumni-portal/utils"
+)
+
+// projectMemberFilter matches projects the user authored or contributes to
+func projectMemberFilter(userID primitive.ObjectID) bson.M {
+	return bson.M{
+		"$or": []bson.M{
+			{"author_id": userID},
+			{"contributors": bson.M{"$elemMatch": bson.M{
+				"user_id": userID,
+				"status":  models.ContributorAccepted,
+			}}},
+		},
+	}
+}
+
+// populateProjectContributors fills in contributor user information. Listings
+// only show accepted contributors; pending invitations are dropped.
+func populateProjectContributors(ctx context.Context, project *models.Project, acceptedOnly bool) {
+	usersCollection := config.GetCollection("users")
+
+	contributors := []models.ProjectContributor{}
+	for _, contributor := range project.Contributors {
+		if acceptedOnly && contributor.Status != models.ContributorAccepted {
+			continue
+		}
+		var user models.User
+		if err := usersCollection.FindOne(ctx, bson.M{"_id": contributor.UserID}).Decode(&user); err == nil {
+			contributor.User = user.ToResponse()
+		}
+		contributors = append(contributors, contributor)
+	}
+	project.Contributors = contributors
+}
+
+func (h *ProjectHandler) InviteContributor(c *fiber.Ctx) error {
+	userID := middleware.GetUserID(c)
+	projectID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid project ID",
+		})
+	}
+
+	var req models.InviteContributorRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid request body",
+		})
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": err.Error(),
+		})
+	}
+
+	inviteeID, err := primitive.ObjectIDFromHex(req.UserID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid user ID",
+		})
+	}
+
+	if inviteeID == userID {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "You are already the owner of this project",
+		})
+	}
+
+	collection := config.GetCollection("projects")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Only the project owner can invite contributors
+	var project models.Project
+	err = collection.FindOne(ctx, bson.M{
+		"_id":       projectID,
+		"author_id": userID,
+		"is_active": true,
+	}).Decode(&project)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "Project not found or access denied",
+		})
+	}
+
+	// Projects are edited through student-only routes, so only students
+	// can join a team
+	var invitee models.User
+	err = config.GetCollection("users").FindOne(ctx, bson.M{
+		"_id":         inviteeID,
+		"role":        models.RoleStudent,
+		"is_verified": true,
+		"is_active":   true,
+	}).Decode(&invitee)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "Student not found",
+		})
+	}
+
+	for _, contributor := range project.Contributors {
+		if contributor.UserID == inviteeID && contributor.Status != models.ContributorDeclined {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error":   true,
+				"message": "User is already a contributor or has a pending invitation",
+			})
+		}
+	}
+
+	contributor := models.ProjectContributor{
+		UserID:    inviteeID,
+		Role:      req.Role,
+		Status:    models.ContributorInvited,
+		InvitedBy: userID,
+		InvitedAt: time.Now(),
+	}
+
+	// Replace any declined invitation with the new one
+	_, err = collection.UpdateOne(ctx, bson.M{"_id": projectID}, bson.M{
+		"$pull": bson.M{"contributors": bson.M{"user_id": inviteeID}},
+	})
+	if err == nil {
+		_, err = collection.UpdateOne(ctx, bson.M{"_id": projectID}, bson.M{
+			"$push": bson.M{"contributors": contributor},
+			"$set":  bson.M{"updated_at": time.Now()},
+		})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to invite contributor",
+		})
+	}
+
+	var owner models.User
+	config.GetCollection("users").FindOne(ctx, bson.M{"_id": userID}).Decode(&owner)
+
+	createNotification(ctx, inviteeID,
+		"Project Invitation",
+		owner.Name+" invited you to join "+project.Title+" as "+string(req.Role),
+		models.NotificationProjectInvite,
+		&project.ID,
+		"project",
+	)
+
+	contributor.User = invitee.ToResponse()
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"error":   false,
+		"message": "Invitation sent successfully",
+		"data":    contributor,
+	})
+}
+
+func (h *ProjectHandler) AcceptInvitation(c *fiber.Ctx) error {
+	return h.respondToInvitation(c, models.ContributorAccepted)
+}
+
+func (h *ProjectHandler) DeclineInvitation(c *fiber.Ctx) error {
+	return h.respondToInvitation(c, models.ContributorDeclined)
+}
+
+func (h *ProjectHandler) respondToInvitation(c *fiber.Ctx, status models.ContributorStatus) error {
+	userID := middleware.GetUserID(c)
+	projectID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid project ID",
+		})
+	}
+
+	collection := config.GetCollection("projects")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	var project models.Project
+	err = collection.FindOneAndUpdate(ctx, bson.M{
+		"_id":       projectID,
+		"is_active": true,
+		"contributors": bson.M{"$elemMatch": bson.M{
+			"user_id": userID,
+			"status":  models.ContributorInvited,
+		}},
+	}, bson.M{
+		"$set": bson.M{
+			"contributors.$.status":       status,
+			"contributors.$.responded_at": now,
+		},
+	}, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&project)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invitation not found",
+		})
+	}
+
+	var user models.User
+	config.GetCollection("users").FindOne(ctx, bson.M{"_id": userID}).Decode(&user)
+
+	title, action := "Project Invitation Accepted", "accepted"
+	if status == models.ContributorDeclined {
+		title, action = "Project Invitation Declined", "declined"
+	}
+	createNotification(ctx, project.AuthorID,
+		title,
+		user.Name+" "+action+" your invitation to "+project.Title,
+		models.NotificationProjectTeam,
+		&project.ID,
+		"project",
+	)
+
+	return c.JSON(fiber.Map{
+		"error":   false,
+		"message": "Invitation " + action,
+	})
+}
+
+func (h *ProjectHandler) UpdateContributor(c *fiber.Ctx) error {
+	userID := middleware.GetUserID(c)
+	projectID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid project ID",
+		})
+	}
+
+	contributorID, err := primitive.ObjectIDFromHex(c.Params("userId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid user ID",
+		})
+	}
+
+	var req models.UpdateContributorRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid request body",
+		})
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": err.Error(),
+		})
+	}
+
+	collection := config.GetCollection("projects")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	result, err := collection.UpdateOne(ctx, bson.M{
+		"_id":                  projectID,
+		"author_id":            userID,
+		"is_active":            true,
+		"contributors.user_id": contributorID,
+	}, bson.M{
+		"$set": bson.M{
+			"contributors.$.role": req.Role,
+			"updated_at":          time.Now(),
+		},
+	})
+	if err != nil || result.MatchedCount == 0 {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "Contributor not found or access denied",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"error":   false,
+		"message": "Contributor updated successfully",
+	})
+}
+
+// RemoveContributor lets the owner remove a contributor, or a contributor leave
+func (h *ProjectHandler) RemoveContributor(c *fiber.Ctx) error {
+	userID := middleware.GetUserID(c)
+	projectID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid project ID",
+		})
+	}
+
+	contributorID, err := primitive.ObjectIDFromHex(c.Params("userId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid user ID",
+		})
+	}
+
+	filter := bson.M{
+		"_id":                  projectID,
+		"is_active":            true,
+		"contributors.user_id": contributorID,
+	}
+	if contributorID != userID {
+		filter["author_id"] = userID
+	}
+
+	collection := config.GetCollection("projects")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var project models.Project
+	err = collection.FindOneAndUpdate(ctx, filter, bson.M{
+		"$pull": bson.M{"contributors": bson.M{"user_id": contributorID}},
+		"$set":  bson.M{"updated_at": time.Now()},
+	}).Decode(&project)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "Contributor not found or access denied",
+		})
+	}
+
+	if contributorID == userID {
+		var user models.User
+		config.GetCollection("users").FindOne(ctx, bson.M{"_id": userID}).Decode(&user)
+		createNotification(ctx, project.AuthorID,
+			"Contributor Left",
+			user.Name+" left "+project.Title,
+			models.NotificationProjectTeam,
+			&project.ID,
+			"project",
+		)
+	}
+
+	return c.JSON(fiber.Map{
+		"error":   false,
+		"message": "Contributor removed successfully",
+	})
+}
+
+// GetInvitations lists the current user's pending project invitations
+func (h *ProjectHandler) GetInvitations(c *fiber.Ctx) error {
+	userID := middleware.GetUserID(c)
+
+	collection := config.GetCollection("projects")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	cursor, err := collection.Find(ctx, bson.M{
+		"is_active": true,
+		"contributors": bson.M{"$elemMatch": bson.M{
+			"user_id": userID,
+			"status":  models.ContributorInvited,
+		}},
+	}, options.Find().SetSort(bson.M{"updated_at": -1}))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to fetch invitations",
+		})
+	}
+	defer cursor.Close(ctx)
+
+	projects := []models.Project{}
+	if err = cursor.All(ctx, &projects); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to decode invitations",
+		})
+	}
+
+	// Populate author information
+	userCollection := config.GetCollection("users")
+	for i := range projects {
+		var user models.User
+		err := userCollection.FindOne(ctx, bson.M{"_id": projects[i].AuthorID}).Decode(&user)
+		if err == nil {
+			projects[i].Author = user.ToResponse()
+		}
+		populateProjectContributors(ctx, &projects[i], true)
+	}
+
+	return c.JSON(fiber.Map{
+		"error": false,
+		"data":  projects,
+	})
+}
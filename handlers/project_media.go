@@ -0,0 +1,663 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"ete-alumni-portal/config"
+	"ete-alumni-portal/middleware"
+	"ete-alumni-portal/models"
+	"ete-alumni-portal/utils"
+)
+
+const projectMediaDir = "./private/project-media"
+
+// legacyProjectMediaDir is where attachments were stored when they were
+// served as static files
+const legacyProjectMediaDir = "./public/project-media"
+
+// maxProjectMedia caps the number of files attached to a single project
+const maxProjectMedia = 20
+
+type projectMediaRule struct {
+	mediaType  models.MediaType
+	extensions []string
+	maxSize    int64
+}
+
+// projectMediaRules maps detected content types to what is accepted for them
+var projectMediaRules = map[string]projectMediaRule{
+	"image/jpeg":      {models.MediaImage, []string{".jpg", ".jpeg"}, 5 * 1024 * 1024},
+	"image/png":       {models.MediaImage, []string{".png"}, 5 * 1024 * 1024},
+	"image/gif":       {models.MediaImage, []string{".gif"}, 5 * 1024 * 1024},
+	"image/webp":      {models.MediaImage, []string{".webp"}, 5 * 1024 * 1024},
+	"application/pdf": {models.MediaDocument, []string{".pdf"}, 20 * 1024 * 1024},
+	"video/mp4":       {models.MediaVideo, []string{".mp4"}, 50 * 1024 * 1024},
+	"video/webm":      {models.MediaVideo, []string{".webm"}, 50 * 1024 * 1024},
+}
+
+func (h *UploadHandler) UploadProjectMedia(c *fiber.Ctx) error {
+	userID := middleware.GetUserID(c)
+	projectID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid project ID",
+		})
+	}
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "No file uploaded",
+		})
+	}
+
+	// Validate file
+	mediaType, contentType, err := h.validateProjectMediaFile(file)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": err.Error(),
+		})
+	}
+
+	caption := utils.SanitizeString(c.FormValue("caption"))
+	if len(caption) > 300 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Caption must be at most 300 characters",
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	project, err := findMemberProject(ctx, projectID, userID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "Project not found or access denied",
+		})
+	}
+
+	collection := config.GetCollection("project_media")
+	count, err := collection.CountDocuments(ctx, bson.M{"project_id": projectID})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to count project media",
+		})
+	}
+	if count >= maxProjectMedia {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": fmt.Sprintf("A project can have at most %d attachments", maxProjectMedia),
+		})
+	}
+
+	// Append after the last attachment
+	position := 0
+	var last models.ProjectMedia
+	err = collection.FindOne(ctx, bson.M{"project_id": projectID},
+		options.FindOne().SetSort(bson.M{"position": -1}),
+	).Decode(&last)
+	if err == nil {
+		position = last.Position + 1
+	}
+
+	// Generate unique filename
+	mediaID := primitive.NewObjectID()
+	ext := strings.ToLower(filepath.Ext(file.Filename))
+	storedName := fmt.Sprintf("%s_%s%s", projectID.Hex(), mediaID.Hex(), ext)
+
+	// Save file
+	if err := c.SaveFile(file, filepath.Join(projectMediaDir, storedName)); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to save file",
+		})
+	}
+
+	media := models.ProjectMedia{
+		ID:          mediaID,
+		ProjectID:   projectID,
+		UploadedBy:  userID,
+		MediaType:   mediaType,
+		FileName:    filepath.Base(file.Filename),
+		StoredName:  storedName,
+		URL:         projectMediaURL(projectID, mediaID),
+		ContentType: contentType,
+		Size:        file.Size,
+		Caption:     caption,
+		Position:    position,
+		// The first image becomes the cover until another is chosen
+		IsCover:   mediaType == models.MediaImage && project.CoverImageURL == "",
+		CreatedAt: time.Now(),
+	}
+
+	if _, err = collection.InsertOne(ctx, media); err != nil {
+		os.Remove(filepath.Join(projectMediaDir, storedName))
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to save project media",
+		})
+	}
+
+	set := bson.M{"updated_at": time.Now()}
+	if media.IsCover {
+		set["cover_image_url"] = media.URL
+	}
+	config.GetCollection("projects").UpdateOne(ctx, bson.M{"_id": projectID}, bson.M{"$set": set})
+	resubmitEditedProject(ctx, &project, userID)
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"error":   false,
+		"message": "File uploaded successfully",
+		"data":    media,
+	})
+}
+
+func (h *UploadHandler) validateProjectMediaFile(file *multipart.FileHeader) (models.MediaType, string, error) {
+	src, err := file.Open()
+	if err != nil {
+		return "", "", fmt.Errorf("failed to open file")
+	}
+	defer src.Close()
+
+	buffer := make([]byte, 512)
+	n, err := src.Read(buffer)
+	if err != nil && err != io.EOF {
+		return "", "", fmt.Errorf("failed to read file")
+	}
+
+	// Check MIME type
+	contentType := http.DetectContentType(buffer[:n])
+	rule, ok := projectMediaRules[contentType]
+	if !ok {
+		return "", "", fmt.Errorf("invalid file type (allowed: jpg, png, gif, webp, pdf, mp4, webm)")
+	}
+
+	// Check file extension matches the content
+	ext := strings.ToLower(filepath.Ext(file.Filename))
+	isValidExt := false
+	for _, allowedExt := range rule.extensions {
+		if ext == allowedExt {
+			isValidExt = true
+			break
+		}
+	}
+	if !isValidExt {
+		return "", "", fmt.Errorf("file extension does not match its content")
+	}
+
+	// Check file size for the media type
+	if file.Size > rule.maxSize {
+		return "", "", fmt.Errorf("%s too large (max %dMB)", rule.mediaType, rule.maxSize/(1024*1024))
+	}
+
+	return rule.mediaType, contentType, nil
+}
+
+func (h *ProjectHandler) GetProjectMedia(c *fiber.Ctx) error {
+	projectID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid project ID",
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var project models.Project
+	err = config.GetCollection("projects").FindOne(ctx, bson.M{
+		"_id":       projectID,
+		"is_active": true,
+	}).Decode(&project)
+	if err != nil || !canViewProject(&project, middleware.GetUserID(c), middleware.GetUserRole(c)) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "Project not found",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"error": false,
+		"data":  loadProjectMedia(ctx, projectID),
+	})
+}
+
+// DownloadProjectMedia streams an attachment to users who can view its project
+func (h *ProjectHandler) DownloadProjectMedia(c *fiber.Ctx) error {
+	projectID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid project ID",
+		})
+	}
+
+	mediaID, err := primitive.ObjectIDFromHex(c.Params("mediaId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid media ID",
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var project models.Project
+	err = config.GetCollection("projects").FindOne(ctx, bson.M{
+		"_id":       projectID,
+		"is_active": true,
+	}).Decode(&project)
+	if err != nil || !canViewProject(&project, middleware.GetUserID(c), middleware.GetUserRole(c)) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "Project not found",
+		})
+	}
+
+	var media models.ProjectMedia
+	err = config.GetCollection("project_media").FindOne(ctx, bson.M{
+		"_id":        mediaID,
+		"project_id": projectID,
+	}).Decode(&media)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "Media not found",
+		})
+	}
+
+	// Media of unpublished projects must not end up in shared caches
+	cacheControl := "private, no-store"
+	if project.IsApproved() {
+		cacheControl = "public, max-age=3600"
+	}
+
+	c.Set(fiber.HeaderContentType, media.ContentType)
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`inline; filename="%s"`, strings.ReplaceAll(media.FileName, `"`, "")))
+	c.Set(fiber.HeaderCacheControl, cacheControl)
+	return c.SendFile(filepath.Join(projectMediaDir, media.StoredName))
+}
+
+func projectMediaURL(projectID, mediaID primitive.ObjectID) string {
+	return "/projects/" + projectID.Hex() + "/media/" + mediaID.Hex() + "/file"
+}
+
+// MigrateProjectMedia moves attachments uploaded while they were served from
+// the public directory into private storage and points their URLs, and any
+// project covers, at the download route. It is safe to run on every start.
+func MigrateProjectMedia() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	collection := config.GetCollection("project_media")
+	cursor, err := collection.Find(ctx, bson.M{"url": bson.M{"$regex": "^/project-media/"}})
+	if err != nil {
+		log.Printf("Failed to load project media for migration: %v", err)
+		return
+	}
+	defer cursor.Close(ctx)
+
+	migrated := 0
+	for cursor.Next(ctx) {
+		var media models.ProjectMedia
+		if err := cursor.Decode(&media); err != nil {
+			continue
+		}
+
+		legacyPath := filepath.Join(legacyProjectMediaDir, media.StoredName)
+		if _, err := os.Stat(legacyPath); err == nil {
+			if err := os.Rename(legacyPath, filepath.Join(projectMediaDir, media.StoredName)); err != nil {
+				log.Printf("Failed to move project media %s: %v", media.ID.Hex(), err)
+				continue
+			}
+		}
+
+		url := projectMediaURL(media.ProjectID, media.ID)
+		collection.UpdateOne(ctx, bson.M{"_id": media.ID}, bson.M{"$set": bson.M{"url": url}})
+		config.GetCollection("projects").UpdateMany(ctx, bson.M{"cover_image_url": media.URL}, bson.M{
+			"$set": bson.M{"cover_image_url": url},
+		})
+		migrated++
+	}
+
+	if migrated > 0 {
+		log.Printf("Moved %d project attachments to private storage", migrated)
+	}
+}
+
+func (h *ProjectHandler) UpdateProjectMedia(c *fiber.Ctx) error {
+	userID := middleware.GetUserID(c)
+	projectID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid project ID",
+		})
+	}
+
+	mediaID, err := primitive.ObjectIDFromHex(c.Params("mediaId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid media ID",
+		})
+	}
+
+	var req models.UpdateProjectMediaRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid request body",
+		})
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": err.Error(),
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	project, err := findMemberProject(ctx, projectID, userID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "Project not found or access denied",
+		})
+	}
+
+	var media models.ProjectMedia
+	err = config.GetCollection("project_media").FindOneAndUpdate(ctx, bson.M{
+		"_id":        mediaID,
+		"project_id": projectID,
+	}, bson.M{
+		"$set": bson.M{"caption": utils.SanitizeString(req.Caption)},
+	}, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&media)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "Media not found",
+		})
+	}
+
+	resubmitEditedProject(ctx, &project, userID)
+
+	return c.JSON(fiber.Map{
+		"error":   false,
+		"message": "Media updated successfully",
+		"data":    media,
+	})
+}
+
+// SetProjectCover makes an attached image the project's cover
+func (h *ProjectHandler) SetProjectCover(c *fiber.Ctx) error {
+	userID := middleware.GetUserID(c)
+	projectID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid project ID",
+		})
+	}
+
+	mediaID, err := primitive.ObjectIDFromHex(c.Params("mediaId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid media ID",
+		})
+	}
+
+	collection := config.GetCollection("project_media")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	project, err := findMemberProject(ctx, projectID, userID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "Project not found or access denied",
+		})
+	}
+
+	var media models.ProjectMedia
+	err = collection.FindOne(ctx, bson.M{
+		"_id":        mediaID,
+		"project_id": projectID,
+	}).Decode(&media)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "Media not found",
+		})
+	}
+
+	if media.MediaType != models.MediaImage {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Only images can be used as the cover",
+		})
+	}
+
+	collection.UpdateMany(ctx, bson.M{"project_id": projectID, "is_cover": true}, bson.M{
+		"$set": bson.M{"is_cover": false},
+	})
+	collection.UpdateOne(ctx, bson.M{"_id": mediaID}, bson.M{
+		"$set": bson.M{"is_cover": true},
+	})
+	config.GetCollection("projects").UpdateOne(ctx, bson.M{"_id": projectID}, bson.M{
+		"$set": bson.M{"cover_image_url": media.URL, "updated_at": time.Now()},
+	})
+	resubmitEditedProject(ctx, &project, userID)
+
+	return c.JSON(fiber.Map{
+		"error":   false,
+		"message": "Cover image updated successfully",
+	})
+}
+
+// ReorderProjectMedia sets the display order; media_ids must list every
+// attachment of the project exactly once
+func (h *ProjectHandler) ReorderProjectMedia(c *fiber.Ctx) error {
+	userID := middleware.GetUserID(c)
+	projectID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid project ID",
+		})
+	}
+
+	var req models.ReorderProjectMediaRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid request body",
+		})
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": err.Error(),
+		})
+	}
+
+	collection := config.GetCollection("project_media")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if _, err := findMemberProject(ctx, projectID, userID); err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "Project not found or access denied",
+		})
+	}
+
+	existing := map[primitive.ObjectID]bool{}
+	for _, media := range loadProjectMedia(ctx, projectID) {
+		existing[media.ID] = true
+	}
+
+	order := []primitive.ObjectID{}
+	seen := map[primitive.ObjectID]bool{}
+	for _, id := range req.MediaIDs {
+		objID, err := primitive.ObjectIDFromHex(id)
+		if err != nil || !existing[objID] || seen[objID] {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   true,
+				"message": "Invalid media ID: " + id,
+			})
+		}
+		seen[objID] = true
+		order = append(order, objID)
+	}
+	if len(order) != len(existing) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "All project media must be included in the order",
+		})
+	}
+
+	for position, mediaID := range order {
+		_, err := collection.UpdateOne(ctx, bson.M{"_id": mediaID}, bson.M{
+			"$set": bson.M{"position": position},
+		})
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error":   true,
+				"message": "Failed to reorder media",
+			})
+		}
+	}
+
+	return c.JSON(fiber.Map{
+		"error":   false,
+		"message": "Media reordered successfully",
+		"data":    loadProjectMedia(ctx, projectID),
+	})
+}
+
+func (h *ProjectHandler) DeleteProjectMedia(c *fiber.Ctx) error {
+	userID := middleware.GetUserID(c)
+	projectID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid project ID",
+		})
+	}
+
+	mediaID, err := primitive.ObjectIDFromHex(c.Params("mediaId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid media ID",
+		})
+	}
+
+	collection := config.GetCollection("project_media")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if _, err := findMemberProject(ctx, projectID, userID); err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "Project not found or access denied",
+		})
+	}
+
+	var media models.ProjectMedia
+	err = collection.FindOneAndDelete(ctx, bson.M{
+		"_id":        mediaID,
+		"project_id": projectID,
+	}).Decode(&media)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "Media not found",
+		})
+	}
+
+	os.Remove(filepath.Join(projectMediaDir, media.StoredName))
+
+	// Fall back to the next image when the cover is removed
+	if media.IsCover {
+		update := bson.M{"$unset": bson.M{"cover_image_url": ""}}
+		var next models.ProjectMedia
+		err := collection.FindOneAndUpdate(ctx, bson.M{
+			"project_id": projectID,
+			"media_type": models.MediaImage,
+		}, bson.M{
+			"$set": bson.M{"is_cover": true},
+		}, options.FindOneAndUpdate().SetSort(bson.M{"position": 1})).Decode(&next)
+		if err == nil {
+			update = bson.M{"$set": bson.M{"cover_image_url": next.URL}}
+		}
+		config.GetCollection("projects").UpdateOne(ctx, bson.M{"_id": projectID}, update)
+	}
+
+	return c.JSON(fiber.Map{
+		"error":   false,
+		"message": "Media deleted successfully",
+	})
+}
+
+// findMemberProject loads an active project the user authored or contributes to
+func findMemberProject(ctx context.Context, projectID, userID primitive.ObjectID) (models.Project, error) {
+	var project models.Project
+	err := config.GetCollection("projects").FindOne(ctx, bson.M{
+		"_id":       projectID,
+		"is_active": true,
+		"$and":      []bson.M{projectMemberFilter(userID)},
+	}).Decode(&project)
+	return project, err
+}
+
+// loadProjectMedia returns a project's attachments in display order
+func loadProjectMedia(ctx context.Context, projectID primitive.ObjectID) []models.ProjectMedia {
+	media := []models.ProjectMedia{}
+	cursor, err := config.GetCollection("project_media").Find(ctx, bson.M{"project_id": projectID},
+		options.Find().SetSort(bson.M{"position": 1}))
+	if err != nil {
+		return media
+	}
+	defer cursor.Close(ctx)
+
+	cursor.All(ctx, &media)
+	return media
+}
+
+// deleteProjectMedia removes all attachments of a project, files included
+func deleteProjectMedia(ctx context.Context, projectID primitive.ObjectID) {
+	collection := config.GetCollection("project_media")
+	for _, media := range loadProjectMedia(ctx, projectID) {
+		os.Remove(filepath.Join(projectMediaDir, media.StoredName))
+	}
+	collection.DeleteMany(ctx, bson.M{"project_id": projectID})
+}
@@ -0,0 +1,193 @@
+package handlers
+
+import (
+	"context"
+	"log"
+	"math"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"ete-alumni-portal/config"
+	"ete-alumni-portal/models"
+)
+
+// Trending score weights. Activity loses half its weight every
+// trendingHalfLifeDays, so recent likes and views dominate.
+const (
+	trendingLikeWeight   = 3.0
+	trendingViewWeight   = 1.0
+	trendingHalfLifeDays = 3.0
+)
+
+// rankingWriteBatch is the number of project updates sent per bulk write
+const rankingWriteBatch = 500
+
+// projectSortOrder returns the sort for a GetProjects ranking mode. Ranked
+// modes read the precomputed ranking; ties fall back to newest first.
+func projectSortOrder(sortBy, period string) bson.D {
+	field := ""
+	switch sortBy {
+	case "trending":
+		field = "ranking.trending_score"
+	case "most_liked":
+		field = "likes_count"
+		switch period {
+		case "week":
+			field = "ranking.likes_week"
+		case "month":
+			field = "ranking.likes_month"
+		}
+	case "most_viewed":
+		field = "views_count"
+		switch period {
+		case "week":
+			field = "ranking.views_week"
+		case "month":
+			field = "ranking.views_month"
+		}
+	}
+
+	if field == "" {
+		return bson.D{{Key: "created_at", Value: -1}}
+	}
+	return bson.D{{Key: field, Value: -1}, {Key: "created_at", Value: -1}}
+}
+
+// RefreshProjectRankings periodically recomputes project rankings
+func RefreshProjectRankings() {
+	ticker := time.NewTicker(config.GetConfig().ProjectRankingInterval)
+	defer ticker.Stop()
+
+	for {
+		computeProjectRankings()
+		<-ticker.C
+	}
+}
+
+func computeProjectRankings() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	now := time.Now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	monthStart := today.AddDate(0, 0, -29)
+
+	rankings := map[primitive.ObjectID]*models.ProjectRanking{}
+	add := func(projectID primitive.ObjectID, day string, likes, views int) {
+		date, err := time.Parse("2006-01-02", day)
+		if err != nil {
+			return
+		}
+		ranking, ok := rankings[projectID]
+		if !ok {
+			ranking = &models.ProjectRanking{}
+			rankings[projectID] = ranking
+		}
+
+		age := today.Sub(date).Hours() / 24
+		decay := math.Pow(0.5, age/trendingHalfLifeDays)
+		ranking.TrendingScore += (float64(likes)*trendingLikeWeight + float64(views)*trendingViewWeight) * decay
+		ranking.LikesMonth += likes
+		ranking.ViewsMonth += views
+		if age < 7 {
+			ranking.LikesWeek += likes
+			ranking.ViewsWeek += views
+		}
+	}
+
+	// Likes per project per day
+	cursor, err := config.GetCollection("project_likes").Aggregate(ctx, bson.A{
+		bson.M{"$match": bson.M{"created_at": bson.M{"$gte": monthStart}}},
+		bson.M{"$group": bson.M{
+			"_id": bson.M{
+				"project_id": "$project_id",
+				"day":        bson.M{"$dateToString": bson.M{"format": "%Y-%m-%d", "date": "$created_at"}},
+			},
+			"likes": bson.M{"$sum": 1},
+		}},
+	})
+	if err != nil {
+		log.Printf("Failed to aggregate project likes for rankings: %v", err)
+		return
+	}
+	var likes []struct {
+		ID struct {
+			ProjectID primitive.ObjectID `bson:"project_id"`
+			Day       string             `bson:"day"`
+		} `bson:"_id"`
+		Likes int `bson:"likes"`
+	}
+	err = cursor.All(ctx, &likes)
+	cursor.Close(ctx)
+	if err != nil {
+		log.Printf("Failed to decode project likes for rankings: %v", err)
+		return
+	}
+	for _, like := range likes {
+		add(like.ID.ProjectID, like.ID.Day, like.Likes, 0)
+	}
+
+	// Views per project per day
+	cursor, err = config.GetCollection("project_view_stats").Find(ctx, bson.M{
+		"day": bson.M{"$gte": monthStart.Format("2006-01-02")},
+	})
+	if err != nil {
+		log.Printf("Failed to fetch project views for rankings: %v", err)
+		return
+	}
+	var buckets []models.ProjectViewBucket
+	err = cursor.All(ctx, &buckets)
+	cursor.Close(ctx)
+	if err != nil {
+		log.Printf("Failed to decode project views for rankings: %v", err)
+		return
+	}
+	for _, bucket := range buckets {
+		add(bucket.ProjectID, bucket.Day, 0, bucket.Views)
+	}
+
+	// Every active project gets a ranking so inactive ones reset to zero
+	collection := config.GetCollection("projects")
+	projectIDs, err := collection.Distinct(ctx, "_id", bson.M{"is_active": true})
+	if err != nil {
+		log.Printf("Failed to fetch projects for rankings: %v", err)
+		return
+	}
+
+	writes := []mongo.WriteModel{}
+	flush := func() bool {
+		if len(writes) == 0 {
+			return true
+		}
+		if _, err := collection.BulkWrite(ctx, writes); err != nil {
+			log.Printf("Failed to store project rankings: %v", err)
+			return false
+		}
+		writes = writes[:0]
+		return true
+	}
+
+	for _, value := range projectIDs {
+		projectID, ok := value.(primitive.ObjectID)
+		if !ok {
+			continue
+		}
+		ranking := rankings[projectID]
+		if ranking == nil {
+			ranking = &models.ProjectRanking{}
+		}
+		ranking.TrendingScore = math.Round(ranking.TrendingScore*100) / 100
+		ranking.ComputedAt = now
+
+		writes = append(writes, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": projectID}).
+			SetUpdate(bson.M{"$set": bson.M{"ranking": ranking}}))
+		if len(writes) >= rankingWriteBatch && !flush() {
+			return
+		}
+	}
+	flush()
+}
@@ -0,0 +1,517 @@
+package handlers
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"ete-alumni-portal/config"
+	"ete-alumni-portal/middleware"
+	"ete-alumni-portal/models"
+	"ete-alumni-portal/utils"
+)
+
+// approvedProjectStatus matches approved projects, including those created
+// before the review workflow existed
+func approvedProjectStatus() bson.M {
+	return bson.M{"$in": bson.A{models.ProjectApproved, nil}}
+}
+
+// canViewProject reports whether a project that may not be approved yet can
+// be shown to the user
+func canViewProject(project *models.Project, userID primitive.ObjectID, role models.UserRole) bool {
+	if project.IsApproved() || project.IsMember(userID) {
+		return true
+	}
+	return role == models.RoleFaculty || role == models.RoleAdmin
+}
+
+// notifyProjectTeam notifies the author and accepted contributors, skipping
+// the user who made the change
+func notifyProjectTeam(ctx context.Context, project *models.Project, actorID primitive.ObjectID, title, message string, notificationType models.NotificationType) {
+	members := []primitive.ObjectID{project.AuthorID}
+	for _, contributor := range project.Contributors {
+		if contributor.Status == models.ContributorAccepted {
+			members = append(members, contributor.UserID)
+		}
+	}
+
+	for _, memberID := range members {
+		if memberID == actorID {
+			continue
+		}
+		createNotification(ctx, memberID, title, message, notificationType, &project.ID, "project")
+	}
+}
+
+// resubmitEditedProject sends an approved project back for review after its
+// members change what the public sees, so unreviewed content is never listed.
+// It returns the project's status after the check.
+func resubmitEditedProject(ctx context.Context, project *models.Project, actorID primitive.ObjectID) models.ProjectStatus {
+	if !project.IsApproved() {
+		return project.Status
+	}
+
+	now := time.Now()
+	var updated models.Project
+	err := config.GetCollection("projects").FindOneAndUpdate(ctx, bson.M{
+		"_id":    project.ID,
+		"status": approvedProjectStatus(),
+	}, bson.M{
+		"$set": bson.M{
+			"status":       models.ProjectSubmitted,
+			"submitted_at": now,
+			"updated_at":   now,
+		},
+	}, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&updated)
+	if err != nil {
+		return project.Status
+	}
+
+	notifyProjectTeam(ctx, &updated, actorID,
+		"Project Sent for Review",
+		updated.Title+" was edited and is hidden until it is approved again",
+		models.NotificationProjectReview,
+	)
+	return updated.Status
+}
+
+// SubmitProject sends a draft, or a project with requested changes, for review
+func (h *ProjectHandler) SubmitProject(c *fiber.Ctx) error {
+	userID := middleware.GetUserID(c)
+	projectID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid project ID",
+		})
+	}
+
+	collection := config.GetCollection("projects")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var existing models.Project
+	err = collection.FindOne(ctx, bson.M{
+		"_id":       projectID,
+		"is_active": true,
+		"$and":      []bson.M{projectMemberFilter(userID)},
+	}).Decode(&existing)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "Project not found or access denied",
+		})
+	}
+
+	if existing.Status != models.ProjectDraft && existing.Status != models.ProjectChangesRequested {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Only drafts or projects with requested changes can be submitted",
+		})
+	}
+
+	// Resubmissions go straight back to the assigned reviewer
+	status := models.ProjectSubmitted
+	if existing.ReviewerID != nil {
+		status = models.ProjectUnderReview
+	}
+
+	now := time.Now()
+	var project models.Project
+	err = collection.FindOneAndUpdate(ctx, bson.M{
+		"_id":    projectID,
+		"status": existing.Status,
+	}, bson.M{
+		"$set": bson.M{
+			"status":       status,
+			"submitted_at": now,
+			"updated_at":   now,
+		},
+	}, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&project)
+	if err != nil {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error":   true,
+			"message": "Project status changed, please try again",
+		})
+	}
+
+	notifyProjectTeam(ctx, &project, userID,
+		"Project Submitted",
+		project.Title+" was submitted for review",
+		models.NotificationProjectReview,
+	)
+	if project.ReviewerID != nil {
+		createNotification(ctx, *project.ReviewerID,
+			"Project Resubmitted",
+			project.Title+" has been resubmitted for your review",
+			models.NotificationProjectReview,
+			&project.ID,
+			"project",
+		)
+	}
+
+	return c.JSON(fiber.Map{
+		"error":   false,
+		"message": "Project submitted for review",
+		"data":    project,
+	})
+}
+
+// AssignReviewer assigns a faculty reviewer and starts the review. Admins can
+// assign anyone on the faculty; faculty members can only pick up a project
+// themselves.
+func (h *ProjectHandler) AssignReviewer(c *fiber.Ctx) error {
+	userID := middleware.GetUserID(c)
+	userRole := middleware.GetUserRole(c)
+	projectID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid project ID",
+		})
+	}
+
+	var req models.AssignReviewerRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid request body",
+		})
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": err.Error(),
+		})
+	}
+
+	reviewerID, err := primitive.ObjectIDFromHex(req.ReviewerID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid reviewer ID",
+		})
+	}
+
+	if userRole == models.RoleFaculty && reviewerID != userID {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error":   true,
+			"message": "Faculty can only assign themselves as reviewer",
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var reviewer models.User
+	err = config.GetCollection("users").FindOne(ctx, bson.M{
+		"_id":       reviewerID,
+		"role":      models.RoleFaculty,
+		"is_active": true,
+	}).Decode(&reviewer)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "Faculty reviewer not found",
+		})
+	}
+
+	var project models.Project
+	err = config.GetCollection("projects").FindOneAndUpdate(ctx, bson.M{
+		"_id":       projectID,
+		"is_active": true,
+		"status":    bson.M{"$in": []models.ProjectStatus{models.ProjectSubmitted, models.ProjectUnderReview}},
+	}, bson.M{
+		"$set": bson.M{
+			"status":      models.ProjectUnderReview,
+			"reviewer_id": reviewerID,
+			"updated_at":  time.Now(),
+		},
+	}, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&project)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "Project not found or not awaiting review",
+		})
+	}
+
+	notifyProjectTeam(ctx, &project, userID,
+		"Project Under Review",
+		reviewer.Name+" is now reviewing "+project.Title,
+		models.NotificationProjectReview,
+	)
+	if reviewerID != userID {
+		createNotification(ctx, reviewerID,
+			"Review Assigned",
+			"You have been assigned to review "+project.Title,
+			models.NotificationProjectReview,
+			&project.ID,
+			"project",
+		)
+	}
+
+	project.Reviewer = reviewer.ToResponse()
+
+	return c.JSON(fiber.Map{
+		"error":   false,
+		"message": "Reviewer assigned successfully",
+		"data":    project,
+	})
+}
+
+// SubmitReview records the assigned reviewer's rubric scores and decision
+func (h *ProjectHandler) SubmitReview(c *fiber.Ctx) error {
+	userID := middleware.GetUserID(c)
+	projectID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid project ID",
+		})
+	}
+
+	var req models.SubmitReviewRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid request body",
+		})
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": err.Error(),
+		})
+	}
+
+	collection := config.GetCollection("projects")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	update := bson.M{
+		"$set": bson.M{
+			"status":     models.ProjectChangesRequested,
+			"updated_at": now,
+		},
+	}
+	if req.Decision == models.ReviewApproved {
+		update["$set"].(bson.M)["status"] = models.ProjectApproved
+		update["$set"].(bson.M)["approved_at"] = now
+	}
+
+	var project models.Project
+	err = collection.FindOneAndUpdate(ctx, bson.M{
+		"_id":         projectID,
+		"is_active":   true,
+		"reviewer_id": userID,
+		"status":      models.ProjectUnderReview,
+	}, update, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&project)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "Project not found or not assigned to you for review",
+		})
+	}
+
+	review := models.ProjectReview{
+		ID:             primitive.NewObjectID(),
+		ProjectID:      projectID,
+		ProjectVersion: max(project.Version, 1),
+		ReviewerID:     userID,
+		Decision:       req.Decision,
+		Scores:         req.Scores,
+		TotalScore:     req.Scores.Total(),
+		MaxScore:       models.RubricMaxScore,
+		Comments:       utils.SanitizeString(req.Comments),
+		CreatedAt:      now,
+	}
+
+	_, err = config.GetCollection("project_reviews").InsertOne(ctx, review)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to save review",
+		})
+	}
+
+	title, message := "Changes Requested", "Your reviewer requested changes to "+project.Title
+	if req.Decision == models.ReviewApproved {
+		title, message = "Project Approved", project.Title+" was approved and is now public"
+	}
+	notifyProjectTeam(ctx, &project, userID, title, message, models.NotificationProjectReview)
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"error":   false,
+		"message": "Review submitted successfully",
+		"data":    review,
+	})
+}
+
+// GetProjectReviews lists a project's reviews for its members and faculty
+func (h *ProjectHandler) GetProjectReviews(c *fiber.Ctx) error {
+	userID := middleware.GetUserID(c)
+	userRole := middleware.GetUserRole(c)
+	projectID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid project ID",
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var project models.Project
+	err = config.GetCollection("projects").FindOne(ctx, bson.M{
+		"_id":       projectID,
+		"is_active": true,
+	}).Decode(&project)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "Project not found",
+		})
+	}
+
+	if !project.IsMember(userID) && userRole != models.RoleFaculty && userRole != models.RoleAdmin {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error":   true,
+			"message": "Access denied",
+		})
+	}
+
+	cursor, err := config.GetCollection("project_reviews").Find(ctx, bson.M{"project_id": projectID},
+		options.Find().SetSort(bson.M{"created_at": -1}))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to fetch reviews",
+		})
+	}
+	defer cursor.Close(ctx)
+
+	reviews := []models.ProjectReview{}
+	if err = cursor.All(ctx, &reviews); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to decode reviews",
+		})
+	}
+
+	// Populate reviewer information
+	userCollection := config.GetCollection("users")
+	for i := range reviews {
+		var user models.User
+		err := userCollection.FindOne(ctx, bson.M{"_id": reviews[i].ReviewerID}).Decode(&user)
+		if err == nil {
+			reviews[i].Reviewer = user.ToResponse()
+		}
+	}
+
+	return c.JSON(fiber.Map{
+		"error": false,
+		"data":  reviews,
+	})
+}
+
+// GetReviewQueue lists projects awaiting review. By default faculty see
+// unassigned submissions and projects assigned to them.
+func (h *ProjectHandler) GetReviewQueue(c *fiber.Ctx) error {
+	userID := middleware.GetUserID(c)
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+	assigned := c.Query("assigned")
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	filter := bson.M{"is_active": true}
+	switch assigned {
+	case "me":
+		filter["status"] = models.ProjectUnderReview
+		filter["reviewer_id"] = userID
+	case "none":
+		filter["status"] = models.ProjectSubmitted
+	case "all":
+		filter["status"] = bson.M{"$in": []models.ProjectStatus{models.ProjectSubmitted, models.ProjectUnderReview}}
+	default:
+		filter["$or"] = []bson.M{
+			{"status": models.ProjectSubmitted},
+			{"status": models.ProjectUnderReview, "reviewer_id": userID},
+		}
+	}
+
+	collection := config.GetCollection("projects")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	total, err := collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to count projects",
+		})
+	}
+
+	// Oldest submissions first
+	skip := (page - 1) * limit
+	opts := options.Find().
+		SetSkip(int64(skip)).
+		SetLimit(int64(limit)).
+		SetSort(bson.M{"submitted_at": 1})
+
+	cursor, err := collection.Find(ctx, filter, opts)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to fetch projects",
+		})
+	}
+	defer cursor.Close(ctx)
+
+	projects := []models.Project{}
+	if err = cursor.All(ctx, &projects); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to decode projects",
+		})
+	}
+
+	// Populate author information
+	userCollection := config.GetCollection("users")
+	for i := range projects {
+		var user models.User
+		err := userCollection.FindOne(ctx, bson.M{"_id": projects[i].AuthorID}).Decode(&user)
+		if err == nil {
+			projects[i].Author = user.ToResponse()
+		}
+		populateProjectContributors(ctx, &projects[i], true)
+	}
+
+	return c.JSON(fiber.Map{
+		"error": false,
+		"data": fiber.Map{
+			"projects": projects,
+			"pagination": fiber.Map{
+				"page":        page,
+				"limit":       limit,
+				"total":       total,
+				"total_pages": (total + int64(limit) - 1) / int64(limit),
+			},
+		},
+	})
+}
@@ -0,0 +1,361 @@
+package handlers
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"ete-alumni-portal/config"
+	"ete-alumni-portal/middleware"
+	"ete-alumni-portal/models"
+	"ete-alumni-portal/utils"
+)
+
+// recordProjectVersion stores the edited project as a new version when its
+// versioned content changed, returning the project's current version.
+// Projects created before versioning get their previous state recorded as
+// version 1 first.
+func recordProjectVersion(ctx context.Context, before, after *models.Project, editorID primitive.ObjectID, restoredFrom *int) int {
+	if len(before.Snapshot().Diff(after.Snapshot())) == 0 {
+		return before.Version
+	}
+
+	projects := config.GetCollection("projects")
+	versions := config.GetCollection("project_versions")
+
+	if before.Version == 0 {
+		versions.InsertOne(ctx, models.ProjectVersion{
+			ID:        primitive.NewObjectID(),
+			ProjectID: before.ID,
+			Version:   1,
+			Snapshot:  before.Snapshot(),
+			EditedBy:  before.AuthorID,
+			CreatedAt: before.UpdatedAt,
+		})
+		projects.UpdateOne(ctx, bson.M{"_id": before.ID, "version": bson.M{"$in": bson.A{0, nil}}}, bson.M{
+			"$set": bson.M{"version": 1},
+		})
+	}
+
+	var current models.Project
+	err := projects.FindOneAndUpdate(ctx, bson.M{"_id": after.ID}, bson.M{
+		"$inc": bson.M{"version": 1},
+	}, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&current)
+	if err != nil {
+		return before.Version
+	}
+
+	versions.InsertOne(ctx, models.ProjectVersion{
+		ID:           primitive.NewObjectID(),
+		ProjectID:    after.ID,
+		Version:      current.Version,
+		Snapshot:     after.Snapshot(),
+		EditedBy:     editorID,
+		RestoredFrom: restoredFrom,
+		CreatedAt:    time.Now(),
+	})
+
+	return current.Version
+}
+
+// loadVersionedProject loads a project whose history the user may see:
+// members, faculty and admins
+func loadVersionedProject(ctx context.Context, c *fiber.Ctx) (*models.Project, error) {
+	userID := middleware.GetUserID(c)
+	userRole := middleware.GetUserRole(c)
+	projectID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return nil, c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid project ID",
+		})
+	}
+
+	var project models.Project
+	err = config.GetCollection("projects").FindOne(ctx, bson.M{
+		"_id":       projectID,
+		"is_active": true,
+	}).Decode(&project)
+	if err != nil || (!project.IsMember(userID) && userRole != models.RoleFaculty && userRole != models.RoleAdmin) {
+		return nil, c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "Project not found or access denied",
+		})
+	}
+
+	return &project, nil
+}
+
+// findProjectVersion returns a stored version. Projects without history only
+// have their current state, which is reported as version 1.
+func findProjectVersion(ctx context.Context, project *models.Project, version int) (*models.ProjectVersion, error) {
+	if project.Version == 0 && version == 1 {
+		return &models.ProjectVersion{
+			ProjectID: project.ID,
+			Version:   1,
+			Snapshot:  project.Snapshot(),
+			EditedBy:  project.AuthorID,
+			CreatedAt: project.UpdatedAt,
+		}, nil
+	}
+
+	var projectVersion models.ProjectVersion
+	err := config.GetCollection("project_versions").FindOne(ctx, bson.M{
+		"project_id": project.ID,
+		"version":    version,
+	}).Decode(&projectVersion)
+	if err != nil {
+		return nil, err
+	}
+	return &projectVersion, nil
+}
+
+func (h *ProjectHandler) GetProjectVersions(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	project, err := loadVersionedProject(ctx, c)
+	if project == nil {
+		return err
+	}
+
+	cursor, err := config.GetCollection("project_versions").Find(ctx, bson.M{"project_id": project.ID},
+		options.Find().SetSort(bson.M{"version": -1}))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to fetch versions",
+		})
+	}
+	defer cursor.Close(ctx)
+
+	versions := []models.ProjectVersion{}
+	if err = cursor.All(ctx, &versions); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to decode versions",
+		})
+	}
+
+	if len(versions) == 0 {
+		if current, err := findProjectVersion(ctx, project, 1); err == nil {
+			versions = append(versions, *current)
+		}
+	}
+
+	// Populate editor information
+	userCollection := config.GetCollection("users")
+	editors := map[primitive.ObjectID]*models.UserResponse{}
+	for i := range versions {
+		editor, ok := editors[versions[i].EditedBy]
+		if !ok {
+			var user models.User
+			if err := userCollection.FindOne(ctx, bson.M{"_id": versions[i].EditedBy}).Decode(&user); err == nil {
+				editor = user.ToResponse()
+			}
+			editors[versions[i].EditedBy] = editor
+		}
+		versions[i].Editor = editor
+	}
+
+	return c.JSON(fiber.Map{
+		"error": false,
+		"data": fiber.Map{
+			"current_version": max(project.Version, 1),
+			"versions":        versions,
+		},
+	})
+}
+
+func (h *ProjectHandler) GetProjectVersion(c *fiber.Ctx) error {
+	version, err := strconv.Atoi(c.Params("version"))
+	if err != nil || version < 1 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid version",
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	project, err := loadVersionedProject(ctx, c)
+	if project == nil {
+		return err
+	}
+
+	projectVersion, err := findProjectVersion(ctx, project, version)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "Version not found",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"error": false,
+		"data":  projectVersion,
+	})
+}
+
+// DiffProjectVersions compares two versions field by field. "to" defaults to
+// the current version and "from" to the one before it; from=last_review
+// compares against the version the latest review was given on.
+func (h *ProjectHandler) DiffProjectVersions(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	project, err := loadVersionedProject(ctx, c)
+	if project == nil {
+		return err
+	}
+
+	current := max(project.Version, 1)
+	to := current
+	if c.Query("to") != "" {
+		if to, err = strconv.Atoi(c.Query("to")); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   true,
+				"message": "Invalid to version",
+			})
+		}
+	}
+
+	from := to - 1
+	switch c.Query("from") {
+	case "":
+	case "last_review":
+		var review models.ProjectReview
+		err := config.GetCollection("project_reviews").FindOne(ctx, bson.M{"project_id": project.ID},
+			options.FindOne().SetSort(bson.M{"created_at": -1}),
+		).Decode(&review)
+		if err != nil || review.ProjectVersion == 0 {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error":   true,
+				"message": "No reviewed version found",
+			})
+		}
+		from = review.ProjectVersion
+	default:
+		if from, err = strconv.Atoi(c.Query("from")); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   true,
+				"message": "Invalid from version",
+			})
+		}
+	}
+
+	if from < 1 {
+		from = 1
+	}
+
+	fromVersion, err := findProjectVersion(ctx, project, from)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "Version " + strconv.Itoa(from) + " not found",
+		})
+	}
+	toVersion, err := findProjectVersion(ctx, project, to)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "Version " + strconv.Itoa(to) + " not found",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"error": false,
+		"data": models.ProjectDiff{
+			FromVersion: from,
+			ToVersion:   to,
+			Changes:     fromVersion.Snapshot.Diff(toVersion.Snapshot),
+		},
+	})
+}
+
+// RestoreProjectVersion makes an older version current again. The restore is
+// recorded as a new version so nothing is lost.
+func (h *ProjectHandler) RestoreProjectVersion(c *fiber.Ctx) error {
+	userID := middleware.GetUserID(c)
+	projectID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid project ID",
+		})
+	}
+
+	version, err := strconv.Atoi(c.Params("version"))
+	if err != nil || version < 1 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid version",
+		})
+	}
+
+	collection := config.GetCollection("projects")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	existing, err := findMemberProject(ctx, projectID, userID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "Project not found or access denied",
+		})
+	}
+
+	projectVersion, err := findProjectVersion(ctx, &existing, version)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "Version not found",
+		})
+	}
+
+	snapshot := projectVersion.Snapshot
+	if len(existing.Snapshot().Diff(snapshot)) == 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Project already matches this version",
+		})
+	}
+
+	var project models.Project
+	err = collection.FindOneAndUpdate(ctx, bson.M{"_id": projectID}, bson.M{
+		"$set": bson.M{
+			"title":        utils.SanitizeString(snapshot.Title),
+			"description":  utils.SanitizeString(snapshot.Description),
+			"project_type": snapshot.ProjectType,
+			"technologies": snapshot.Technologies,
+			"github_url":   snapshot.GitHubURL,
+			"demo_url":     snapshot.DemoURL,
+			"updated_at":   time.Now(),
+		},
+	}, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&project)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to restore version",
+		})
+	}
+
+	project.Version = recordProjectVersion(ctx, &existing, &project, userID, &version)
+	project.Status = resubmitEditedProject(ctx, &existing, userID)
+
+	if project.GitHubURL != "" && project.GitHubURL != existing.GitHubURL {
+		queueRepoMetadataRefresh(project.ID)
+	}
+
+	return c.JSON(fiber.Map{
+		"error":   false,
+		"message": "Version restored successfully",
+		"data":    project,
+	})
+}
@@ -0,0 +1,228 @@
+package handlers
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"ete-alumni-portal/config"
+	"ete-alumni-portal/middleware"
+	"ete-alumni-portal/models"
+)
+
+// botUserAgents are user agent fragments of crawlers, link previews and
+// scripted clients whose fetches are not counted as views
+var botUserAgents = []string{
+	"bot", "crawler", "spider", "slurp", "preview", "headless",
+	"facebookexternalhit", "curl", "wget", "python-requests", "httpclient",
+}
+
+func isBotUserAgent(userAgent string) bool {
+	userAgent = strings.ToLower(userAgent)
+	if userAgent == "" {
+		return true
+	}
+	for _, fragment := range botUserAgents {
+		if strings.Contains(userAgent, fragment) {
+			return true
+		}
+	}
+	return false
+}
+
+// recordProjectView counts a view unless it comes from a project member, a
+// bot, or a viewer already counted within the dedupe window
+func recordProjectView(ctx context.Context, project *models.Project, viewerID primitive.ObjectID, userAgent string) {
+	if project.IsMember(viewerID) || isBotUserAgent(userAgent) {
+		return
+	}
+
+	now := time.Now()
+	day := now.UTC().Format("2006-01-02")
+	windowStart := now.Add(-config.GetConfig().ProjectViewWindow)
+
+	views := config.GetCollection("project_views")
+	_, err := views.UpdateOne(ctx, bson.M{
+		"project_id": project.ID,
+		"viewer_id":  viewerID,
+	}, bson.M{
+		"$set":         bson.M{"last_viewed_at": now},
+		"$setOnInsert": bson.M{"first_viewed_at": now},
+	}, options.Update().SetUpsert(true))
+	if err != nil {
+		return
+	}
+
+	// Claim the count in a single conditional update so parallel requests
+	// cannot all see the old counted_at; only the one that matched counts
+	var previous models.ProjectView
+	err = views.FindOneAndUpdate(ctx, bson.M{
+		"project_id": project.ID,
+		"viewer_id":  viewerID,
+		"$or": []bson.M{
+			{"counted_at": bson.M{"$exists": false}},
+			{"counted_at": bson.M{"$lt": windowStart}},
+		},
+	}, bson.M{
+		"$set": bson.M{"counted_at": now},
+	}).Decode(&previous)
+	if err != nil {
+		return
+	}
+	firstView := previous.CountedAt.IsZero()
+
+	// Daily bucket; a viewer counts once per day towards unique viewers
+	inc := bson.M{"views": 1}
+	if firstView || previous.CountedAt.UTC().Format("2006-01-02") != day {
+		inc["viewers"] = 1
+	}
+	config.GetCollection("project_view_stats").UpdateOne(ctx, bson.M{
+		"project_id": project.ID,
+		"day":        day,
+	}, bson.M{"$inc": inc}, options.Update().SetUpsert(true))
+
+	config.GetCollection("projects").UpdateOne(ctx, bson.M{"_id": project.ID}, bson.M{
+		"$inc": bson.M{"views_count": 1},
+	})
+}
+
+// GetProjectAnalytics returns daily views and likes for a project's members
+func (h *ProjectHandler) GetProjectAnalytics(c *fiber.Ctx) error {
+	userID := middleware.GetUserID(c)
+	userRole := middleware.GetUserRole(c)
+	projectID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid project ID",
+		})
+	}
+
+	days, _ := strconv.Atoi(c.Query("days", "30"))
+	if days < 1 || days > 365 {
+		days = 30
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var project models.Project
+	err = config.GetCollection("projects").FindOne(ctx, bson.M{
+		"_id":       projectID,
+		"is_active": true,
+	}).Decode(&project)
+	if err != nil || (!project.IsMember(userID) && userRole != models.RoleAdmin) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "Project not found or access denied",
+		})
+	}
+
+	now := time.Now().UTC()
+	start := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, -(days - 1))
+	since := start.Format("2006-01-02")
+
+	// Views per day
+	cursor, err := config.GetCollection("project_view_stats").Find(ctx, bson.M{
+		"project_id": projectID,
+		"day":        bson.M{"$gte": since},
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to fetch project views",
+		})
+	}
+	defer cursor.Close(ctx)
+
+	var buckets []models.ProjectViewBucket
+	if err = cursor.All(ctx, &buckets); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to decode project views",
+		})
+	}
+
+	// Likes per day
+	cursor, err = config.GetCollection("project_likes").Aggregate(ctx, bson.A{
+		bson.M{"$match": bson.M{
+			"project_id": projectID,
+			"created_at": bson.M{"$gte": start},
+		}},
+		bson.M{"$group": bson.M{
+			"_id":   bson.M{"$dateToString": bson.M{"format": "%Y-%m-%d", "date": "$created_at"}},
+			"likes": bson.M{"$sum": 1},
+		}},
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to fetch project likes",
+		})
+	}
+	defer cursor.Close(ctx)
+
+	var likes []struct {
+		Day   string `bson:"_id"`
+		Likes int    `bson:"likes"`
+	}
+	if err = cursor.All(ctx, &likes); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to decode project likes",
+		})
+	}
+
+	viewerIDs, err := config.GetCollection("project_views").Distinct(ctx, "viewer_id", bson.M{
+		"project_id": projectID,
+		"counted_at": bson.M{"$gte": start},
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to count viewers",
+		})
+	}
+
+	// Fill every day of the period so charts have no gaps
+	series := make([]models.ProjectAnalyticsDay, days)
+	index := map[string]int{}
+	for i := range series {
+		series[i].Day = start.AddDate(0, 0, i).Format("2006-01-02")
+		index[series[i].Day] = i
+	}
+
+	totalViews, totalLikes := 0, 0
+	for _, bucket := range buckets {
+		if i, ok := index[bucket.Day]; ok {
+			series[i].Views = bucket.Views
+			series[i].Viewers = bucket.Viewers
+			totalViews += bucket.Views
+		}
+	}
+	for _, like := range likes {
+		if i, ok := index[like.Day]; ok {
+			series[i].Likes = like.Likes
+			totalLikes += like.Likes
+		}
+	}
+
+	return c.JSON(fiber.Map{
+		"error": false,
+		"data": fiber.Map{
+			"days":           days,
+			"total_views":    totalViews,
+			"unique_viewers": len(viewerIDs),
+			"total_likes":    totalLikes,
+			"lifetime_views": project.ViewsCount,
+			"lifetime_likes": project.LikesCount,
+			"daily":          series,
+		},
+	})
+}
@@ -0,0 +1,366 @@
+package handlers
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"ete-alumni-portal/config"
+	"ete-alumni-portal/middleware"
+	"ete-alumni-portal/models"
+)
+
+// Weights used to score "people you may know" candidates
+const (
+	scoreGraduationYear = 20
+	scoreSameCompany    = 15
+	scoreSameLocation   = 10
+	scorePerSkill       = 10
+	scorePerEvent       = 5
+	scorePerContact     = 8
+
+	maxRecommendations = 50
+)
+
+type RecommendationHandler struct{}
+
+func NewRecommendationHandler() *RecommendationHandler {
+	return &RecommendationHandler{}
+}
+
+func (h *RecommendationHandler) GetPeopleYouMayKnow(c *fiber.Ctx) error {
+	userID := middleware.GetUserID(c)
+	limit, _ := strconv.Atoi(c.Query("limit", "10"))
+	refresh := c.Query("refresh") == "true"
+
+	if limit < 1 || limit > maxRecommendations {
+		limit = 10
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	collection := config.GetCollection("people_recommendations")
+
+	var cache models.RecommendationCache
+	err := collection.FindOne(ctx, bson.M{
+		"user_id":    userID,
+		"expires_at": bson.M{"$gt": time.Now()},
+	}).Decode(&cache)
+	if err != nil || refresh {
+		recommendations, err := computePeopleYouMayKnow(ctx, userID)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error":   true,
+				"message": "Failed to compute recommendations",
+			})
+		}
+
+		now := time.Now()
+		cache = models.RecommendationCache{
+			UserID:          userID,
+			Recommendations: recommendations,
+			ComputedAt:      now,
+			ExpiresAt:       now.Add(config.GetConfig().RecommendationCacheTTL),
+		}
+
+		collection.UpdateOne(ctx, bson.M{"user_id": userID}, bson.M{
+			"$set": bson.M{
+				"recommendations": cache.Recommendations,
+				"computed_at":     cache.ComputedAt,
+				"expires_at":      cache.ExpiresAt,
+			},
+		}, options.Update().SetUpsert(true))
+	}
+
+	// Populate user information, skipping anyone contacted since the cache was
+	// built, until the page is full
+	contacted, _ := contactedUserIDs(ctx, userID)
+	usersCollection := config.GetCollection("users")
+	results := []models.PersonRecommendation{}
+	for _, recommendation := range cache.Recommendations {
+		if len(results) == limit {
+			break
+		}
+		if contacted[recommendation.UserID] {
+			continue
+		}
+
+		var user models.User
+		err := usersCollection.FindOne(ctx, bson.M{
+			"_id":       recommendation.UserID,
+			"is_active": true,
+		}).Decode(&user)
+		if err != nil {
+			continue
+		}
+
+		recommendation.User = user.ToResponseFor(userID, false)
+		if recommendation.User.ProfileRestricted {
+			continue
+		}
+		results = append(results, recommendation)
+	}
+
+	return c.JSON(fiber.Map{
+		"error": false,
+		"data": fiber.Map{
+			"recommendations": results,
+			"computed_at":     cache.ComputedAt,
+		},
+	})
+}
+
+// contactedUserIDs returns everyone userID has exchanged messages with or has a
+// pending or accepted connection with. These are never recommended.
+func contactedUserIDs(ctx context.Context, userID primitive.ObjectID) (map[primitive.ObjectID]bool, error) {
+	contacted, err := messagePartnerIDs(ctx, userID)
+	if err != nil {
+		return contacted, err
+	}
+
+	cursor, err := config.GetCollection("connections").Find(ctx, bson.M{
+		"status": bson.M{"$in": []models.ConnectionStatus{models.ConnectionPending, models.ConnectionAccepted}},
+		"$or": []bson.M{
+			{"requester_id": userID},
+			{"addressee_id": userID},
+		},
+	})
+	if err != nil {
+		return contacted, err
+	}
+	defer cursor.Close(ctx)
+
+	var connections []models.Connection
+	if err = cursor.All(ctx, &connections); err != nil {
+		return contacted, err
+	}
+
+	for _, connection := range connections {
+		if connection.RequesterID == userID {
+			contacted[connection.AddresseeID] = true
+		} else {
+			contacted[connection.RequesterID] = true
+		}
+	}
+
+	return contacted, nil
+}
+
+// messagePartnerIDs returns the users userID has sent messages to or received messages from
+func messagePartnerIDs(ctx context.Context, userID primitive.ObjectID) (map[primitive.ObjectID]bool, error) {
+	partners := make(map[primitive.ObjectID]bool)
+	collection := config.GetCollection("messages")
+
+	recipients, err := collection.Distinct(ctx, "recipient_id", bson.M{"sender_id": userID})
+	if err != nil {
+		return partners, err
+	}
+	senders, err := collection.Distinct(ctx, "sender_id", bson.M{"recipient_id": userID})
+	if err != nil {
+		return partners, err
+	}
+
+	for _, value := range append(recipients, senders...) {
+		if id, ok := value.(primitive.ObjectID); ok {
+			partners[id] = true
+		}
+	}
+
+	return partners, nil
+}
+
+// computePeopleYouMayKnow scores every eligible user against userID in a
+// single aggregation and returns the best candidates, highest score first.
+func computePeopleYouMayKnow(ctx context.Context, userID primitive.ObjectID) ([]models.PersonRecommendation, error) {
+	var user models.User
+	if err := config.GetCollection("users").FindOne(ctx, bson.M{"_id": userID}).Decode(&user); err != nil {
+		return nil, err
+	}
+
+	contacted, err := contactedUserIDs(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	partners, err := messagePartnerIDs(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	excluded := []primitive.ObjectID{userID}
+	for id := range contacted {
+		excluded = append(excluded, id)
+	}
+	partnerIDs := []primitive.ObjectID{}
+	for id := range partners {
+		partnerIDs = append(partnerIDs, id)
+	}
+
+	mySkills := []string{}
+	for _, skill := range user.Skills {
+		if key := skillKey(skill); key != "" {
+			mySkills = append(mySkills, key)
+		}
+	}
+
+	eventIDs, err := config.GetCollection("event_rsvps").Distinct(ctx, "event_id", bson.M{
+		"user_id": userID,
+		"status":  bson.M{"$in": []models.RSVPStatus{models.RSVPAttending, models.RSVPMaybe}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	myEvents := []primitive.ObjectID{}
+	for _, value := range eventIDs {
+		if id, ok := value.(primitive.ObjectID); ok {
+			myEvents = append(myEvents, id)
+		}
+	}
+
+	// Text fields are compared case-insensitively and only when set on both sides
+	sameField := func(field, value string) bson.M {
+		key := skillKey(value)
+		if key == "" {
+			return bson.M{"$literal": false}
+		}
+		return bson.M{"$eq": bson.A{
+			bson.M{"$toLower": bson.M{"$trim": bson.M{"input": bson.M{"$ifNull": bson.A{"$" + field, ""}}}}},
+			key,
+		}}
+	}
+
+	pipeline := bson.A{
+		bson.M{"$match": bson.M{
+			"_id":                        bson.M{"$nin": excluded},
+			"is_active":                  true,
+			"privacy.profile_visibility": bson.M{"$ne": models.VisibilityPrivate},
+		}},
+		bson.M{"$lookup": bson.M{
+			"from": "event_rsvps",
+			"let":  bson.M{"uid": "$_id"},
+			"pipeline": bson.A{
+				bson.M{"$match": bson.M{
+					"event_id": bson.M{"$in": myEvents},
+					"status":   bson.M{"$in": []models.RSVPStatus{models.RSVPAttending, models.RSVPMaybe}},
+					"$expr":    bson.M{"$eq": bson.A{"$user_id", "$$uid"}},
+				}},
+				bson.M{"$group": bson.M{"_id": "$event_id"}},
+			},
+			"as": "shared_events",
+		}},
+		bson.M{"$lookup": bson.M{
+			"from": "messages",
+			"let":  bson.M{"uid": "$_id"},
+			"pipeline": bson.A{
+				bson.M{"$match": bson.M{
+					"$or": bson.A{
+						bson.M{"recipient_id": bson.M{"$in": partnerIDs}},
+						bson.M{"sender_id": bson.M{"$in": partnerIDs}},
+					},
+					"$expr": bson.M{"$or": bson.A{
+						bson.M{"$eq": bson.A{"$sender_id", "$$uid"}},
+						bson.M{"$eq": bson.A{"$recipient_id", "$$uid"}},
+					}},
+				}},
+				bson.M{"$project": bson.M{
+					"partner": bson.M{"$cond": bson.A{
+						bson.M{"$eq": bson.A{"$sender_id", "$$uid"}},
+						"$recipient_id",
+						"$sender_id",
+					}},
+				}},
+				bson.M{"$match": bson.M{"partner": bson.M{"$in": partnerIDs}}},
+				bson.M{"$group": bson.M{"_id": "$partner"}},
+			},
+			"as": "mutual_contacts",
+		}},
+		bson.M{"$addFields": bson.M{
+			"shared_skills": bson.M{"$setIntersection": bson.A{
+				bson.M{"$map": bson.M{
+					"input": bson.M{"$ifNull": bson.A{"$skills", bson.A{}}},
+					"as":    "skill",
+					"in":    bson.M{"$toLower": bson.M{"$trim": bson.M{"input": "$$skill"}}},
+				}},
+				mySkills,
+			}},
+			"same_year": bson.M{"$and": bson.A{
+				user.GraduationYear != 0,
+				bson.M{"$eq": bson.A{"$graduation_year", user.GraduationYear}},
+			}},
+			"same_company":  sameField("company", user.Company),
+			"same_location": sameField("location", user.Location),
+		}},
+		bson.M{"$addFields": bson.M{
+			"score": bson.M{"$add": bson.A{
+				bson.M{"$cond": bson.A{"$same_year", scoreGraduationYear, 0}},
+				bson.M{"$cond": bson.A{"$same_company", scoreSameCompany, 0}},
+				bson.M{"$cond": bson.A{"$same_location", scoreSameLocation, 0}},
+				bson.M{"$multiply": bson.A{bson.M{"$size": "$shared_skills"}, scorePerSkill}},
+				bson.M{"$multiply": bson.A{bson.M{"$size": "$shared_events"}, scorePerEvent}},
+				bson.M{"$multiply": bson.A{bson.M{"$size": "$mutual_contacts"}, scorePerContact}},
+			}},
+		}},
+		bson.M{"$match": bson.M{"score": bson.M{"$gt": 0}}},
+		bson.M{"$sort": bson.D{{Key: "score", Value: -1}, {Key: "name", Value: 1}}},
+		bson.M{"$limit": maxRecommendations},
+		bson.M{"$project": bson.M{
+			"_id":             0,
+			"user_id":         "$_id",
+			"score":           1,
+			"shared_skills":   1,
+			"same_year":       1,
+			"same_company":    1,
+			"same_location":   1,
+			"shared_events":   bson.M{"$size": "$shared_events"},
+			"mutual_contacts": bson.M{"$size": "$mutual_contacts"},
+		}},
+	}
+
+	cursor, err := config.GetCollection("users").Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var candidates []struct {
+		models.PersonRecommendation `bson:",inline"`
+		SameYear                    bool `bson:"same_year"`
+		SameCompany                 bool `bson:"same_company"`
+		SameLocation                bool `bson:"same_location"`
+	}
+	if err = cursor.All(ctx, &candidates); err != nil {
+		return nil, err
+	}
+
+	recommendations := make([]models.PersonRecommendation, 0, len(candidates))
+	for _, candidate := range candidates {
+		recommendation := candidate.PersonRecommendation
+		recommendation.Reasons = []string{}
+		if candidate.SameYear {
+			recommendation.Reasons = append(recommendation.Reasons, "Same graduation year")
+		}
+		if candidate.SameCompany {
+			recommendation.Reasons = append(recommendation.Reasons, "Works at "+user.Company)
+		}
+		if candidate.SameLocation {
+			recommendation.Reasons = append(recommendation.Reasons, "Based in "+user.Location)
+		}
+		if n := len(recommendation.SharedSkills); n > 0 {
+			recommendation.Reasons = append(recommendation.Reasons, strconv.Itoa(n)+" shared skills")
+		}
+		if recommendation.SharedEvents > 0 {
+			recommendation.Reasons = append(recommendation.Reasons, strconv.Itoa(recommendation.SharedEvents)+" shared events")
+		}
+		if recommendation.MutualContacts > 0 {
+			recommendation.Reasons = append(recommendation.Reasons, strconv.Itoa(recommendation.MutualContacts)+" mutual contacts")
+		}
+		recommendations = append(recommendations, recommendation)
+	}
+
+	return recommendations, nil
+}
@@ -0,0 +1,530 @@
+package handlers
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"ete-alumni-portal/config"
+	"ete-alumni-portal/middleware"
+	"ete-alumni-portal/models"
+	"ete-alumni-portal/utils"
+)
+
+type ReferralHandler struct{}
+
+func NewReferralHandler() *ReferralHandler {
+	return &ReferralHandler{}
+}
+
+// companyAlumniFilter matches verified alumni whose current company is the
+// given one, ignoring case and surrounding spaces
+func companyAlumniFilter(company string) bson.M {
+	return bson.M{
+		"role":        models.RoleAlumni,
+		"is_verified": true,
+		"is_active":   true,
+		"company": bson.M{
+			"$regex":   "^\\s*" + regexp.QuoteMeta(strings.TrimSpace(company)) + "\\s*$",
+			"$options": "i",
+		},
+	}
+}
+
+// GetReferrers lists alumni who work at the job's company and can be asked
+// for a referral
+func (h *ReferralHandler) GetReferrers(c *fiber.Ctx) error {
+	userID := middleware.GetUserID(c)
+	jobID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid job ID",
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := visibleJobFilter()
+	filter["_id"] = jobID
+
+	var job models.Job
+	if err := config.GetCollection("jobs").FindOne(ctx, filter).Decode(&job); err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "Job not found",
+		})
+	}
+
+	cursor, err := config.GetCollection("users").Find(ctx, companyAlumniFilter(job.Company),
+		options.Find().SetSort(bson.M{"name": 1}).SetLimit(100))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to fetch alumni",
+		})
+	}
+	defer cursor.Close(ctx)
+
+	var users []models.User
+	if err = cursor.All(ctx, &users); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to decode alumni",
+		})
+	}
+
+	alumni := []*models.UserResponse{}
+	for i := range users {
+		alumni = append(alumni, users[i].ToResponseFor(userID, areConnected(ctx, userID, users[i].ID)))
+	}
+
+	return c.JSON(fiber.Map{
+		"error": false,
+		"data":  alumni,
+	})
+}
+
+// CreateReferral asks an alumnus at the job's company for a referral. The
+// student's chosen or latest resume is attached, and a student can only have
+// a limited number of pending requests.
+func (h *ReferralHandler) CreateReferral(c *fiber.Ctx) error {
+	userID := middleware.GetUserID(c)
+
+	var req models.CreateReferralRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid request body",
+		})
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": err.Error(),
+		})
+	}
+
+	jobID, err := primitive.ObjectIDFromHex(req.JobID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid job ID",
+		})
+	}
+	alumnusID, err := primitive.ObjectIDFromHex(req.AlumnusID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid alumnus ID",
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	collection := config.GetCollection("referral_requests")
+
+	jobFilter := visibleJobFilter()
+	jobFilter["_id"] = jobID
+
+	var job models.Job
+	if err := config.GetCollection("jobs").FindOne(ctx, jobFilter).Decode(&job); err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "Job not found",
+		})
+	}
+
+	alumnusFilter := companyAlumniFilter(job.Company)
+	alumnusFilter["_id"] = alumnusID
+
+	var alumnus models.User
+	if err := config.GetCollection("users").FindOne(ctx, alumnusFilter).Decode(&alumnus); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "This alumnus does not work at " + job.Company,
+		})
+	}
+
+	existing, err := collection.CountDocuments(ctx, bson.M{
+		"job_id":     jobID,
+		"student_id": userID,
+		"alumnus_id": alumnusID,
+		"status":     bson.M{"$in": []models.ReferralStatus{models.ReferralPending, models.ReferralAccepted}},
+	})
+	if err == nil && existing > 0 {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error":   true,
+			"message": "You already asked this alumnus for a referral to this job",
+		})
+	}
+
+	// Attach the chosen resume, or the latest one
+	resumeFilter := bson.M{"user_id": userID}
+	if req.ResumeID != "" {
+		resumeID, err := primitive.ObjectIDFromHex(req.ResumeID)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   true,
+				"message": "Invalid resume ID",
+			})
+		}
+		resumeFilter["_id"] = resumeID
+	}
+
+	var resume models.Resume
+	err = config.GetCollection("resumes").FindOne(ctx, resumeFilter,
+		options.FindOne().SetSort(bson.M{"version": -1}),
+	).Decode(&resume)
+	if err != nil && req.ResumeID != "" {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "Resume not found",
+		})
+	}
+
+	now := time.Now()
+	referral := models.ReferralRequest{
+		ID:        primitive.NewObjectID(),
+		JobID:     jobID,
+		StudentID: userID,
+		AlumnusID: alumnusID,
+		Note:      utils.SanitizeString(req.Note),
+		Status:    models.ReferralPending,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err == nil {
+		referral.ResumeID = &resume.ID
+	}
+
+	// Limit open requests so alumni are not flooded
+	limit := config.GetConfig().ReferralOpenLimit
+	if !reserveOpenReferral(ctx, userID, limit) {
+		return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
+			"error":   true,
+			"message": "You can have at most " + strconv.Itoa(limit) + " pending referral requests",
+		})
+	}
+
+	if _, err := collection.InsertOne(ctx, referral); err != nil {
+		releaseOpenReferral(ctx, userID)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to send referral request",
+		})
+	}
+
+	var student models.User
+	config.GetCollection("users").FindOne(ctx, bson.M{"_id": userID}).Decode(&student)
+	createNotification(ctx, alumnusID, "Referral Request",
+		student.Name+" asked you for a referral for "+job.Title+" at "+job.Company,
+		models.NotificationReferral, &referral.ID, "referral")
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"error":   false,
+		"message": "Referral request sent successfully",
+		"data":    referral,
+	})
+}
+
+// GetReferrals lists referral requests the user sent (box=sent) or received
+// (box=received). Alumni see received requests by default, everyone else
+// their sent ones.
+func (h *ReferralHandler) GetReferrals(c *fiber.Ctx) error {
+	userID := middleware.GetUserID(c)
+	box := c.Query("box")
+	status := c.Query("status")
+
+	if box == "" {
+		box = "sent"
+		if middleware.GetUserRole(c) == models.RoleAlumni {
+			box = "received"
+		}
+	}
+
+	filter := bson.M{"student_id": userID}
+	if box == "received" {
+		filter = bson.M{"alumnus_id": userID}
+	}
+	if status != "" {
+		filter["status"] = status
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	cursor, err := config.GetCollection("referral_requests").Find(ctx, filter,
+		options.Find().SetSort(bson.M{"created_at": -1}))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to fetch referral requests",
+		})
+	}
+	defer cursor.Close(ctx)
+
+	referrals := []models.ReferralRequest{}
+	if err = cursor.All(ctx, &referrals); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to decode referral requests",
+		})
+	}
+
+	// Populate job, people and resume information
+	jobsCollection := config.GetCollection("jobs")
+	usersCollection := config.GetCollection("users")
+	resumesCollection := config.GetCollection("resumes")
+	for i := range referrals {
+		var job models.Job
+		if err := jobsCollection.FindOne(ctx, bson.M{"_id": referrals[i].JobID}).Decode(&job); err == nil {
+			referrals[i].Job = &job
+		}
+
+		var student models.User
+		if err := usersCollection.FindOne(ctx, bson.M{"_id": referrals[i].StudentID}).Decode(&student); err == nil {
+			referrals[i].Student = student.ToResponse()
+		}
+
+		var alumnus models.User
+		if err := usersCollection.FindOne(ctx, bson.M{"_id": referrals[i].AlumnusID}).Decode(&alumnus); err == nil {
+			referrals[i].Alumnus = alumnus.ToResponse()
+		}
+
+		if referrals[i].ResumeID != nil {
+			var resume models.Resume
+			if err := resumesCollection.FindOne(ctx, bson.M{"_id": referrals[i].ResumeID}).Decode(&resume); err == nil {
+				referrals[i].Resume = &resume
+			}
+		}
+	}
+
+	return c.JSON(fiber.Map{
+		"error": false,
+		"data":  referrals,
+	})
+}
+
+func (h *ReferralHandler) AcceptReferral(c *fiber.Ctx) error {
+	return h.respondToReferral(c, models.ReferralAccepted)
+}
+
+func (h *ReferralHandler) DeclineReferral(c *fiber.Ctx) error {
+	return h.respondToReferral(c, models.ReferralDeclined)
+}
+
+// respondToReferral records the alumnus' answer to a pending request
+func (h *ReferralHandler) respondToReferral(c *fiber.Ctx, status models.ReferralStatus) error {
+	userID := middleware.GetUserID(c)
+	referralID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid referral request ID",
+		})
+	}
+
+	var req models.RespondReferralRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   true,
+				"message": "Invalid request body",
+			})
+		}
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": err.Error(),
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	var referral models.ReferralRequest
+	err = config.GetCollection("referral_requests").FindOneAndUpdate(ctx, bson.M{
+		"_id":        referralID,
+		"alumnus_id": userID,
+		"status":     models.ReferralPending,
+	}, bson.M{
+		"$set": bson.M{
+			"status":        status,
+			"response_note": utils.SanitizeString(req.Note),
+			"responded_at":  now,
+			"updated_at":    now,
+		},
+	}, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&referral)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "Pending referral request not found",
+		})
+	}
+	releaseOpenReferral(ctx, referral.StudentID)
+
+	var alumnus models.User
+	config.GetCollection("users").FindOne(ctx, bson.M{"_id": userID}).Decode(&alumnus)
+	var job models.Job
+	config.GetCollection("jobs").FindOne(ctx, bson.M{"_id": referral.JobID}).Decode(&job)
+
+	message := alumnus.Name + " agreed to refer you for " + job.Title + " at " + job.Company
+	if status == models.ReferralDeclined {
+		message = alumnus.Name + " can't refer you for " + job.Title + " at " + job.Company
+	}
+	createNotification(ctx, referral.StudentID, "Referral Request Update", message,
+		models.NotificationReferral, &referral.ID, "referral")
+
+	return c.JSON(fiber.Map{
+		"error":   false,
+		"message": "Referral request " + string(status),
+		"data":    referral,
+	})
+}
+
+// CancelReferral lets the student withdraw a request the alumnus has not
+// answered yet
+func (h *ReferralHandler) CancelReferral(c *fiber.Ctx) error {
+	userID := middleware.GetUserID(c)
+	referralID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid referral request ID",
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	result, err := config.GetCollection("referral_requests").UpdateOne(ctx, bson.M{
+		"_id":        referralID,
+		"student_id": userID,
+		"status":     models.ReferralPending,
+	}, bson.M{
+		"$set": bson.M{
+			"status":     models.ReferralCancelled,
+			"updated_at": time.Now(),
+		},
+	})
+	if err != nil || result.MatchedCount == 0 {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "Pending referral request not found",
+		})
+	}
+	releaseOpenReferral(ctx, userID)
+
+	return c.JSON(fiber.Map{
+		"error":   false,
+		"message": "Referral request cancelled",
+	})
+}
+
+// UpdateReferralOutcome records what came of an accepted referral. Either
+// side can report it and the other is notified.
+func (h *ReferralHandler) UpdateReferralOutcome(c *fiber.Ctx) error {
+	userID := middleware.GetUserID(c)
+	referralID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid referral request ID",
+		})
+	}
+
+	var req models.UpdateReferralOutcomeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid request body",
+		})
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": err.Error(),
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	var referral models.ReferralRequest
+	err = config.GetCollection("referral_requests").FindOneAndUpdate(ctx, bson.M{
+		"_id":    referralID,
+		"status": models.ReferralAccepted,
+		"$or": []bson.M{
+			{"student_id": userID},
+			{"alumnus_id": userID},
+		},
+	}, bson.M{
+		"$set": bson.M{
+			"outcome":    req.Outcome,
+			"outcome_at": now,
+			"updated_at": now,
+		},
+	}, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&referral)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "Accepted referral request not found",
+		})
+	}
+
+	var job models.Job
+	config.GetCollection("jobs").FindOne(ctx, bson.M{"_id": referral.JobID}).Decode(&job)
+
+	otherID := referral.StudentID
+	if otherID == userID {
+		otherID = referral.AlumnusID
+	}
+	createNotification(ctx, otherID, "Referral Outcome Updated",
+		"The referral for "+job.Title+" at "+job.Company+" is now marked "+strings.ReplaceAll(string(req.Outcome), "_", " "),
+		models.NotificationReferral, &referral.ID, "referral")
+
+	return c.JSON(fiber.Map{
+		"error":   false,
+		"message": "Referral outcome updated successfully",
+		"data":    referral,
+	})
+}
+
+// reserveOpenReferral claims one of the student's pending referral slots,
+// tracked in open_referrals on the user. The limit check and the increment
+// are one update so concurrent requests cannot exceed the limit.
+func reserveOpenReferral(ctx context.Context, studentID primitive.ObjectID, limit int) bool {
+	result, err := config.GetCollection("users").UpdateOne(ctx, bson.M{
+		"_id": studentID,
+		"$or": []bson.M{
+			{"open_referrals": bson.M{"$lt": limit}},
+			{"open_referrals": bson.M{"$exists": false}},
+		},
+	}, bson.M{"$inc": bson.M{"open_referrals": 1}})
+	return err == nil && result.ModifiedCount > 0
+}
+
+// releaseOpenReferral frees a slot once a pending request is answered,
+// cancelled or could not be saved
+func releaseOpenReferral(ctx context.Context, studentID primitive.ObjectID) {
+	config.GetCollection("users").UpdateOne(ctx, bson.M{
+		"_id":            studentID,
+		"open_referrals": bson.M{"$gt": 0},
+	}, bson.M{"$inc": bson.M{"open_referrals": -1}})
+}
@@ -0,0 +1,231 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"ete-alumni-portal/config"
+	"ete-alumni-portal/middleware"
+	"ete-alumni-portal/models"
+	"ete-alumni-portal/utils"
+)
+
+// RepoFetcher loads repository metadata for projects. It defaults to the
+// GitHub fetcher and can be replaced before the server starts.
+var RepoFetcher utils.RepoMetadataFetcher
+
+var repoFetcherOnce sync.Once
+
+func repoFetcher() utils.RepoMetadataFetcher {
+	repoFetcherOnce.Do(func() {
+		if RepoFetcher == nil {
+			RepoFetcher = utils.NewGitHubFetcher()
+		}
+	})
+	return RepoFetcher
+}
+
+// minSyncedLanguageShare is the share of code a language needs before it is
+// added to a project's technologies
+const minSyncedLanguageShare = 5.0
+
+// refreshProjectRepoMetadata fetches and stores metadata for the project's
+// repository, adding its main languages to the technologies when enabled
+func refreshProjectRepoMetadata(ctx context.Context, project *models.Project) (*models.RepoMetadata, error) {
+	collection := config.GetCollection("projects")
+
+	metadata, err := repoFetcher().Fetch(ctx, project.GitHubURL)
+	if errors.Is(err, utils.ErrUnsupportedRepo) {
+		// Drop metadata from a previous repository but keep fetched_at, so
+		// the hourly refresh doesn't pick the project again on every run
+		collection.UpdateOne(ctx, bson.M{"_id": project.ID}, bson.M{
+			"$set": bson.M{"repo_metadata": models.RepoMetadata{
+				FetchedAt:  time.Now(),
+				FetchError: err.Error(),
+			}},
+		})
+		return nil, err
+	}
+	if err != nil {
+		// Keep the last good metadata and record the failure
+		collection.UpdateOne(ctx, bson.M{"_id": project.ID}, bson.M{
+			"$set": bson.M{
+				"repo_metadata.fetch_error": err.Error(),
+				"repo_metadata.fetched_at":  time.Now(),
+			},
+		})
+		return nil, err
+	}
+
+	// Only add languages the project does not list yet. They are added with
+	// $addToSet rather than by writing back the loaded list, which could undo
+	// an edit made while the repository was being fetched.
+	var added []string
+	if project.SyncTechnologies {
+		known := map[string]bool{}
+		for _, technology := range project.Technologies {
+			known[skillKey(technology)] = true
+		}
+		for _, language := range metadata.Languages {
+			if language.Percent >= minSyncedLanguageShare && !known[skillKey(language.Name)] {
+				known[skillKey(language.Name)] = true
+				added = append(added, language.Name)
+			}
+		}
+	}
+
+	if len(added) == 0 {
+		_, err = collection.UpdateOne(ctx, bson.M{"_id": project.ID}, bson.M{
+			"$set": bson.M{"repo_metadata": metadata},
+		})
+		return metadata, err
+	}
+
+	var before models.Project
+	err = collection.FindOneAndUpdate(ctx, bson.M{"_id": project.ID}, bson.M{
+		"$set":      bson.M{"repo_metadata": metadata, "updated_at": time.Now()},
+		"$addToSet": bson.M{"technologies": bson.M{"$each": added}},
+	}, options.FindOneAndUpdate().SetReturnDocument(options.Before)).Decode(&before)
+	if err != nil {
+		return metadata, err
+	}
+
+	// Synced technologies are recorded in the history like any other edit,
+	// without an editor
+	var after models.Project
+	if err := collection.FindOne(ctx, bson.M{"_id": project.ID}).Decode(&after); err == nil {
+		recordProjectVersion(ctx, &before, &after, primitive.NilObjectID, nil)
+	}
+	return metadata, nil
+}
+
+// queueRepoMetadataRefresh refreshes a project's repository metadata in the
+// background so saving the project is not held up by the repository host
+func queueRepoMetadataRefresh(projectID primitive.ObjectID) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+
+		var project models.Project
+		err := config.GetCollection("projects").FindOne(ctx, bson.M{"_id": projectID}).Decode(&project)
+		if err != nil || project.GitHubURL == "" {
+			return
+		}
+
+		if _, err := refreshProjectRepoMetadata(ctx, &project); err != nil && !errors.Is(err, utils.ErrUnsupportedRepo) {
+			log.Printf("Failed to fetch repository metadata for project %s: %v", projectID.Hex(), err)
+		}
+	}()
+}
+
+// RefreshRepoMetadata periodically refreshes stale repository metadata
+func RefreshRepoMetadata() {
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+
+	for {
+		refreshStaleRepoMetadata()
+		<-ticker.C
+	}
+}
+
+// repoMetadataBatchSize limits the number of repositories fetched per run to
+// stay within the host's rate limits
+const repoMetadataBatchSize = 50
+
+func refreshStaleRepoMetadata() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	staleBefore := time.Now().Add(-config.GetConfig().RepoMetadataRefresh)
+	cursor, err := config.GetCollection("projects").Find(ctx, bson.M{
+		"is_active":  true,
+		"github_url": bson.M{"$nin": bson.A{"", nil}},
+		"$or": []bson.M{
+			{"repo_metadata.fetched_at": bson.M{"$exists": false}},
+			{"repo_metadata.fetched_at": bson.M{"$lt": staleBefore}},
+		},
+	}, options.Find().
+		SetSort(bson.M{"repo_metadata.fetched_at": 1}).
+		SetLimit(repoMetadataBatchSize))
+	if err != nil {
+		log.Printf("Failed to fetch projects for repository metadata refresh: %v", err)
+		return
+	}
+	defer cursor.Close(ctx)
+
+	var projects []models.Project
+	if err := cursor.All(ctx, &projects); err != nil {
+		log.Printf("Failed to decode projects for repository metadata refresh: %v", err)
+		return
+	}
+
+	refreshed := 0
+	for i := range projects {
+		if _, err := refreshProjectRepoMetadata(ctx, &projects[i]); err == nil {
+			refreshed++
+		}
+	}
+
+	if refreshed > 0 {
+		log.Printf("Refreshed repository metadata for %d projects", refreshed)
+	}
+}
+
+// RefreshProjectRepoMetadata lets project members refresh the metadata now
+func (h *ProjectHandler) RefreshProjectRepoMetadata(c *fiber.Ctx) error {
+	userID := middleware.GetUserID(c)
+	projectID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid project ID",
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	project, err := findMemberProject(ctx, projectID, userID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "Project not found or access denied",
+		})
+	}
+
+	if project.GitHubURL == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Project has no repository URL",
+		})
+	}
+
+	metadata, err := refreshProjectRepoMetadata(ctx, &project)
+	if errors.Is(err, utils.ErrUnsupportedRepo) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Only GitHub repositories are supported",
+		})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusBadGateway).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to fetch repository metadata",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"error":   false,
+		"message": "Repository metadata refreshed successfully",
+		"data":    metadata,
+	})
+}
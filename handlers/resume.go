@@ -0,0 +1,406 @@
+package handlers
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"ete-alumni-portal/config"
+	"ete-alumni-portal/middleware"
+	"ete-alumni-portal/models"
+)
+
+// Resumes live outside ./public so they are never served by app.Static
+const resumeDir = "./private/resumes"
+
+const (
+	contentTypePDF  = "application/pdf"
+	contentTypeDOCX = "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+)
+
+func (h *UploadHandler) UploadResume(c *fiber.Ctx) error {
+	userID := middleware.GetUserID(c)
+
+	file, err := c.FormFile("resume")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "No file uploaded",
+		})
+	}
+
+	// Validate file
+	contentType, err := h.validateResumeFile(file)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": err.Error(),
+		})
+	}
+
+	collection := config.GetCollection("resumes")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Next version number
+	version := 1
+	var latest models.Resume
+	err = collection.FindOne(ctx, bson.M{"user_id": userID},
+		options.FindOne().SetSort(bson.M{"version": -1}),
+	).Decode(&latest)
+	if err == nil {
+		version = latest.Version + 1
+	}
+
+	// Generate unique filename
+	ext := strings.ToLower(filepath.Ext(file.Filename))
+	storedName := fmt.Sprintf("%s_v%d_%d%s", userID.Hex(), version, time.Now().Unix(), ext)
+
+	// Save file
+	if err := c.SaveFile(file, filepath.Join(resumeDir, storedName)); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to save file",
+		})
+	}
+
+	resume := models.Resume{
+		ID:          primitive.NewObjectID(),
+		UserID:      userID,
+		Version:     version,
+		FileName:    filepath.Base(file.Filename),
+		StoredName:  storedName,
+		ContentType: contentType,
+		Size:        file.Size,
+		CreatedAt:   time.Now(),
+	}
+
+	if _, err = collection.InsertOne(ctx, resume); err != nil {
+		os.Remove(filepath.Join(resumeDir, storedName))
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to save resume",
+		})
+	}
+
+	h.pruneResumes(ctx, userID)
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"error":   false,
+		"message": "Resume uploaded successfully",
+		"data":    resume,
+	})
+}
+
+func (h *UploadHandler) DeleteResume(c *fiber.Ctx) error {
+	userID := middleware.GetUserID(c)
+	resumeID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid resume ID",
+		})
+	}
+
+	collection := config.GetCollection("resumes")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var resume models.Resume
+	err = collection.FindOneAndDelete(ctx, bson.M{
+		"_id":     resumeID,
+		"user_id": userID,
+	}).Decode(&resume)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "Resume not found",
+		})
+	}
+
+	os.Remove(filepath.Join(resumeDir, resume.StoredName))
+
+	return c.JSON(fiber.Map{
+		"error":   false,
+		"message": "Resume deleted successfully",
+	})
+}
+
+func (h *UploadHandler) GetResumes(c *fiber.Ctx) error {
+	ownerID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid user ID",
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{"user_id": ownerID}
+	all, resumeIDs := accessibleResumes(ctx, middleware.GetUserID(c), middleware.GetUserRole(c), ownerID)
+	if !all {
+		if len(resumeIDs) == 0 {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error":   true,
+				"message": "You are not allowed to view this user's resumes",
+			})
+		}
+		filter["_id"] = bson.M{"$in": resumeIDs}
+	}
+
+	cursor, err := config.GetCollection("resumes").Find(ctx, filter,
+		options.Find().SetSort(bson.M{"version": -1}),
+	)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to fetch resumes",
+		})
+	}
+	defer cursor.Close(ctx)
+
+	resumes := []models.Resume{}
+	if err = cursor.All(ctx, &resumes); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to decode resumes",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"error": false,
+		"data":  resumes,
+	})
+}
+
+// DownloadResume streams a resume version; "latest" selects the newest upload
+func (h *UploadHandler) DownloadResume(c *fiber.Ctx) error {
+	ownerID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid user ID",
+		})
+	}
+
+	var resumeID *primitive.ObjectID
+	if resumeIDStr := c.Params("resumeId"); resumeIDStr != "latest" {
+		id, err := primitive.ObjectIDFromHex(resumeIDStr)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   true,
+				"message": "Invalid resume ID",
+			})
+		}
+		resumeID = &id
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Without full access, "latest" means the newest resume the viewer was sent
+	filter := bson.M{"user_id": ownerID}
+	all, resumeIDs := accessibleResumes(ctx, middleware.GetUserID(c), middleware.GetUserRole(c), ownerID)
+	allowed := all
+	for _, id := range resumeIDs {
+		allowed = allowed || resumeID == nil || id == *resumeID
+	}
+	if !allowed {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error":   true,
+			"message": "You are not allowed to download this resume",
+		})
+	}
+	if resumeID != nil {
+		filter["_id"] = *resumeID
+	} else if !all {
+		filter["_id"] = bson.M{"$in": resumeIDs}
+	}
+
+	var resume models.Resume
+	err = config.GetCollection("resumes").FindOne(ctx, filter,
+		options.FindOne().SetSort(bson.M{"version": -1}),
+	).Decode(&resume)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "Resume not found",
+		})
+	}
+
+	c.Set(fiber.HeaderContentType, resume.ContentType)
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s"`, strings.ReplaceAll(resume.FileName, `"`, "")))
+	c.Set(fiber.HeaderCacheControl, "private, no-store")
+	return c.SendFile(filepath.Join(resumeDir, resume.StoredName))
+}
+
+// accessibleResumes reports which of the owner's resumes the viewer may see.
+// The owner and admins see all of them; job posters and referral alumni only
+// see the resumes attached to the owner's applications and referral requests.
+func accessibleResumes(ctx context.Context, viewerID primitive.ObjectID, role models.UserRole, ownerID primitive.ObjectID) (all bool, resumeIDs []primitive.ObjectID) {
+	if viewerID == ownerID || role == models.RoleAdmin {
+		return true, nil
+	}
+
+	var referrals []models.ReferralRequest
+	cursor, err := config.GetCollection("referral_requests").Find(ctx, bson.M{
+		"student_id": ownerID,
+		"alumnus_id": viewerID,
+		"status":     bson.M{"$in": []models.ReferralStatus{models.ReferralPending, models.ReferralAccepted}},
+		"resume_id":  bson.M{"$ne": nil},
+	})
+	if err == nil {
+		cursor.All(ctx, &referrals)
+		cursor.Close(ctx)
+	}
+	for _, referral := range referrals {
+		resumeIDs = append(resumeIDs, *referral.ResumeID)
+	}
+
+	var applications []models.JobApplication
+	cursor, err = config.GetCollection("job_applications").Find(ctx, bson.M{
+		"user_id":   ownerID,
+		"status":    bson.M{"$ne": models.ApplicationWithdrawn},
+		"resume_id": bson.M{"$ne": nil},
+	})
+	if err == nil {
+		cursor.All(ctx, &applications)
+		cursor.Close(ctx)
+	}
+	if len(applications) == 0 {
+		return false, resumeIDs
+	}
+
+	jobIDs := make([]primitive.ObjectID, 0, len(applications))
+	for _, application := range applications {
+		jobIDs = append(jobIDs, application.JobID)
+	}
+	postedIDs, err := config.GetCollection("jobs").Distinct(ctx, "_id", bson.M{
+		"_id":       bson.M{"$in": jobIDs},
+		"posted_by": viewerID,
+	})
+	if err != nil {
+		return false, resumeIDs
+	}
+
+	posted := make(map[primitive.ObjectID]bool, len(postedIDs))
+	for _, value := range postedIDs {
+		if jobID, ok := value.(primitive.ObjectID); ok {
+			posted[jobID] = true
+		}
+	}
+	for _, application := range applications {
+		if posted[application.JobID] {
+			resumeIDs = append(resumeIDs, *application.ResumeID)
+		}
+	}
+	return false, resumeIDs
+}
+
+// pruneResumes removes all but the newest configured number of versions
+func (h *UploadHandler) pruneResumes(ctx context.Context, userID primitive.ObjectID) {
+	keep := config.GetConfig().ResumeVersionsKept
+	if keep < 1 {
+		keep = 1
+	}
+
+	collection := config.GetCollection("resumes")
+	cursor, err := collection.Find(ctx, bson.M{"user_id": userID},
+		options.Find().SetSort(bson.M{"version": -1}).SetSkip(int64(keep)),
+	)
+	if err != nil {
+		return
+	}
+	defer cursor.Close(ctx)
+
+	var old []models.Resume
+	if err = cursor.All(ctx, &old); err != nil {
+		return
+	}
+
+	// Versions attached to job applications and referral requests stay
+	// available to the alumni reviewing them
+	attached, _ := config.GetCollection("job_applications").Distinct(ctx, "resume_id", bson.M{"user_id": userID})
+	referred, _ := config.GetCollection("referral_requests").Distinct(ctx, "resume_id", bson.M{"student_id": userID})
+	attached = append(attached, referred...)
+	keepIDs := map[primitive.ObjectID]bool{}
+	for _, id := range attached {
+		if resumeID, ok := id.(primitive.ObjectID); ok {
+			keepIDs[resumeID] = true
+		}
+	}
+
+	for _, resume := range old {
+		if keepIDs[resume.ID] {
+			continue
+		}
+		if _, err := collection.DeleteOne(ctx, bson.M{"_id": resume.ID}); err == nil {
+			os.Remove(filepath.Join(resumeDir, resume.StoredName))
+		}
+	}
+}
+
+func (h *UploadHandler) validateResumeFile(file *multipart.FileHeader) (string, error) {
+	// Check file size
+	maxSize := config.GetConfig().ResumeMaxSize
+	if file.Size > maxSize {
+		return "", fmt.Errorf("file size too large (max %dMB)", maxSize/(1024*1024))
+	}
+
+	// Check file extension
+	ext := strings.ToLower(filepath.Ext(file.Filename))
+	if ext != ".pdf" && ext != ".docx" {
+		return "", fmt.Errorf("invalid file type (allowed: pdf, docx)")
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return "", fmt.Errorf("failed to open file")
+	}
+	defer src.Close()
+
+	header := make([]byte, 8)
+	n, err := io.ReadFull(src, header)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return "", fmt.Errorf("failed to read file")
+	}
+	header = header[:n]
+
+	// Check magic bytes rather than trusting the extension
+	switch ext {
+	case ".pdf":
+		if !bytes.HasPrefix(header, []byte("%PDF-")) {
+			return "", fmt.Errorf("invalid file type detected")
+		}
+		return contentTypePDF, nil
+	default:
+		if !bytes.HasPrefix(header, []byte("PK\x03\x04")) {
+			return "", fmt.Errorf("invalid file type detected")
+		}
+		// A DOCX is a zip archive containing a word/document.xml part
+		reader, err := zip.NewReader(src, file.Size)
+		if err != nil {
+			return "", fmt.Errorf("invalid file type detected")
+		}
+		for _, entry := range reader.File {
+			if entry.Name == "word/document.xml" {
+				return contentTypeDOCX, nil
+			}
+		}
+		return "", fmt.Errorf("invalid file type detected")
+	}
+}
@@ -0,0 +1,323 @@
+package handlers
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"ete-alumni-portal/config"
+	"ete-alumni-portal/middleware"
+	"ete-alumni-portal/models"
+	"ete-alumni-portal/utils"
+)
+
+// salaryInsightRounding rounds published figures so exact amounts from a
+// report never appear in insights
+const salaryInsightRounding = 1000
+
+// minSalaryReports is the lowest anonymity threshold accepted from config
+const minSalaryReports = 5
+
+type SalaryHandler struct{}
+
+func NewSalaryHandler() *SalaryHandler {
+	return &SalaryHandler{}
+}
+
+// salarySubmitterHash identifies a report's author without storing their ID.
+// It is keyed with its own secret so it cannot be recomputed from a user ID
+// alone, and is unaffected by rotating the JWT secret.
+func salarySubmitterHash(userID primitive.ObjectID) string {
+	mac := hmac.New(sha256.New, []byte(config.GetConfig().SalaryHashSecret))
+	mac.Write([]byte("salary-report:" + userID.Hex()))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// groupKey normalizes company and role names so "Google " and "google" are
+// reported together
+func groupKey(value string) string {
+	return strings.Join(strings.Fields(strings.ToLower(value)), " ")
+}
+
+// SubmitSalaryReport stores or replaces the alumnus' anonymous report for a
+// company and role
+func (h *SalaryHandler) SubmitSalaryReport(c *fiber.Ctx) error {
+	userID := middleware.GetUserID(c)
+
+	var req models.SubmitSalaryReportRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid request body",
+		})
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": err.Error(),
+		})
+	}
+
+	company := strings.TrimSpace(utils.SanitizeString(req.Company))
+	role := strings.TrimSpace(utils.SanitizeString(req.Role))
+	submitterHash := salarySubmitterHash(userID)
+	now := time.Now()
+
+	compensation := models.Compensation{
+		Min:       req.Amount,
+		Max:       req.Amount,
+		Currency:  strings.ToUpper(req.Currency),
+		Period:    req.Period,
+		HasEquity: req.HasEquity,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var report models.SalaryReport
+	err := config.GetCollection("salary_reports").FindOneAndUpdate(ctx, bson.M{
+		"submitter_hash": submitterHash,
+		"company_key":    groupKey(company),
+		"role_key":       groupKey(role),
+	}, bson.M{
+		"$set": bson.M{
+			"company":          company,
+			"role":             role,
+			"experience_years": req.ExperienceYears,
+			"experience_band":  models.ExperienceBand(req.ExperienceYears),
+			"compensation":     compensation,
+			"annual_amount":    req.Period.Annual(req.Amount),
+			"updated_at":       now,
+		},
+		"$setOnInsert": bson.M{
+			"_id":        primitive.NewObjectID(),
+			"created_at": now,
+		},
+	}, options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)).Decode(&report)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to save salary report",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"error":   false,
+		"message": "Salary report saved anonymously",
+		"data":    report,
+	})
+}
+
+// GetMySalaryReports lists the reports the user submitted
+func (h *SalaryHandler) GetMySalaryReports(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	cursor, err := config.GetCollection("salary_reports").Find(ctx, bson.M{
+		"submitter_hash": salarySubmitterHash(middleware.GetUserID(c)),
+	}, options.Find().SetSort(bson.M{"updated_at": -1}))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to fetch salary reports",
+		})
+	}
+	defer cursor.Close(ctx)
+
+	reports := []models.SalaryReport{}
+	if err = cursor.All(ctx, &reports); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to decode salary reports",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"error": false,
+		"data":  reports,
+	})
+}
+
+func (h *SalaryHandler) DeleteSalaryReport(c *fiber.Ctx) error {
+	reportID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid salary report ID",
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	result, err := config.GetCollection("salary_reports").DeleteOne(ctx, bson.M{
+		"_id":            reportID,
+		"submitter_hash": salarySubmitterHash(middleware.GetUserID(c)),
+	})
+	if err != nil || result.DeletedCount == 0 {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "Salary report not found",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"error":   false,
+		"message": "Salary report deleted successfully",
+	})
+}
+
+// GetSalaryInsights aggregates yearly pay by company, role and experience
+// band, or by the subset named in group_by. Reports are first split into
+// cells by currency, company, role and band, and only cells with at least
+// the configured minimum of reports are used, so every group is made of
+// whole cells of that size and comparing groups cannot single out a report.
+// Only rounded percentiles and a banded report count are published.
+func (h *SalaryHandler) GetSalaryInsights(c *fiber.Ctx) error {
+	company := c.Query("company")
+	role := c.Query("role")
+	band := c.Query("experience_band")
+	currency := strings.ToUpper(c.Query("currency"))
+
+	groupBy := map[string]bool{"company": true, "role": true, "experience_band": true}
+	if c.Query("group_by") != "" {
+		groupBy = map[string]bool{}
+		for _, field := range strings.Split(c.Query("group_by"), ",") {
+			field = strings.TrimSpace(field)
+			if field != "company" && field != "role" && field != "experience_band" {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error":   true,
+					"message": "group_by accepts company, role and experience_band",
+				})
+			}
+			groupBy[field] = true
+		}
+	}
+
+	filter := bson.M{}
+	if company != "" {
+		filter["company_key"] = groupKey(company)
+	}
+	if role != "" {
+		filter["role_key"] = groupKey(role)
+	}
+	if band != "" {
+		filter["experience_band"] = band
+	}
+	if currency != "" {
+		filter["compensation.currency"] = currency
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	cursor, err := config.GetCollection("salary_reports").Find(ctx, filter)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to fetch salary reports",
+		})
+	}
+	defer cursor.Close(ctx)
+
+	var reports []models.SalaryReport
+	if err = cursor.All(ctx, &reports); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to decode salary reports",
+		})
+	}
+
+	// Suppress cells below the threshold before grouping
+	minReports := max(config.GetConfig().SalaryMinReports, minSalaryReports)
+	cells := map[string]int{}
+	for _, report := range reports {
+		cells[salaryCellKey(&report)]++
+	}
+
+	// Group reports; amounts in different currencies are never mixed
+	type group struct {
+		insight models.SalaryInsight
+		amounts []int64
+		equity  int
+	}
+	groups := map[string]*group{}
+	order := []string{}
+	for _, report := range reports {
+		if cells[salaryCellKey(&report)] < minReports {
+			continue
+		}
+
+		insight := models.SalaryInsight{Currency: report.Compensation.Currency}
+		key := report.Compensation.Currency
+		if groupBy["company"] {
+			insight.Company = report.Company
+			key += "|" + report.CompanyKey
+		}
+		if groupBy["role"] {
+			insight.Role = report.Role
+			key += "|" + report.RoleKey
+		}
+		if groupBy["experience_band"] {
+			insight.ExperienceBand = report.ExperienceBand
+			key += "|" + report.ExperienceBand
+		}
+
+		g, ok := groups[key]
+		if !ok {
+			g = &group{insight: insight}
+			groups[key] = g
+			order = append(order, key)
+		}
+		g.amounts = append(g.amounts, report.AnnualAmount)
+		if report.Compensation.HasEquity {
+			g.equity++
+		}
+	}
+
+	sort.SliceStable(order, func(i, j int) bool {
+		return len(groups[order[i]].amounts) > len(groups[order[j]].amounts)
+	})
+
+	insights := []models.SalaryInsight{}
+	for _, key := range order {
+		g := groups[key]
+		sort.Slice(g.amounts, func(i, j int) bool { return g.amounts[i] < g.amounts[j] })
+
+		insight := g.insight
+		insight.Reports = models.ReportCountBand(len(g.amounts))
+		insight.Percentile25 = roundSalary(models.Percentile(g.amounts, 0.25))
+		insight.Median = roundSalary(models.Percentile(g.amounts, 0.5))
+		insight.Percentile75 = roundSalary(models.Percentile(g.amounts, 0.75))
+		insight.EquityShare = math.Round(float64(g.equity)/float64(len(g.amounts))*10) / 10
+		insights = append(insights, insight)
+	}
+
+	return c.JSON(fiber.Map{
+		"error": false,
+		"data": fiber.Map{
+			"insights":    insights,
+			"min_reports": minReports,
+		},
+	})
+}
+
+// salaryCellKey names the finest group a report belongs to
+func salaryCellKey(report *models.SalaryReport) string {
+	return report.Compensation.Currency + "|" + report.CompanyKey + "|" + report.RoleKey + "|" + report.ExperienceBand
+}
+
+func roundSalary(amount float64) int64 {
+	return int64(math.Round(amount/salaryInsightRounding)) * salaryInsightRounding
+}
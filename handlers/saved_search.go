@@ -0,0 +1,419 @@
+package handlers
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"ete-alumni-portal/config"
+	"ete-alumni-portal/middleware"
+	"ete-alumni-portal/models"
+	"ete-alumni-portal/utils"
+)
+
+// cleanTerms sanitizes search terms and drops blanks and duplicates
+func cleanTerms(terms []string) []string {
+	cleaned := []string{}
+	seen := map[string]bool{}
+	for _, term := range terms {
+		term = strings.TrimSpace(utils.SanitizeString(term))
+		if term == "" || seen[strings.ToLower(term)] {
+			continue
+		}
+		seen[strings.ToLower(term)] = true
+		cleaned = append(cleaned, term)
+	}
+	return cleaned
+}
+
+func (h *JobHandler) GetSavedSearches(c *fiber.Ctx) error {
+	userID := middleware.GetUserID(c)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	cursor, err := config.GetCollection("saved_job_searches").Find(ctx, bson.M{"user_id": userID},
+		options.Find().SetSort(bson.M{"created_at": -1}))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to fetch saved searches",
+		})
+	}
+	defer cursor.Close(ctx)
+
+	searches := []models.SavedJobSearch{}
+	if err = cursor.All(ctx, &searches); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to decode saved searches",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"error": false,
+		"data":  searches,
+	})
+}
+
+func (h *JobHandler) CreateSavedSearch(c *fiber.Ctx) error {
+	userID := middleware.GetUserID(c)
+
+	var req models.CreateSavedJobSearchRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid request body",
+		})
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": err.Error(),
+		})
+	}
+
+	collection := config.GetCollection("saved_job_searches")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	count, err := collection.CountDocuments(ctx, bson.M{"user_id": userID})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to count saved searches",
+		})
+	}
+	if count >= models.MaxSavedJobSearches {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "You can save up to " + strconv.Itoa(models.MaxSavedJobSearches) + " searches",
+		})
+	}
+
+	now := time.Now()
+	search := models.SavedJobSearch{
+		ID:        primitive.NewObjectID(),
+		UserID:    userID,
+		Name:      utils.SanitizeString(req.Name),
+		JobType:   req.JobType,
+		Location:  strings.TrimSpace(utils.SanitizeString(req.Location)),
+		Keywords:  cleanTerms(req.Keywords),
+		Skills:    cleanTerms(req.Skills),
+		Frequency: req.Frequency,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if _, err := collection.InsertOne(ctx, search); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to save search",
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"error":   false,
+		"message": "Search saved successfully",
+		"data":    search,
+	})
+}
+
+func (h *JobHandler) UpdateSavedSearch(c *fiber.Ctx) error {
+	userID := middleware.GetUserID(c)
+	searchID, err := primitive.ObjectIDFromHex(c.Params("searchId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid saved search ID",
+		})
+	}
+
+	var req models.UpdateSavedJobSearchRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid request body",
+		})
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": err.Error(),
+		})
+	}
+
+	// Build update document
+	update := bson.M{
+		"$set": bson.M{
+			"updated_at": time.Now(),
+		},
+	}
+
+	if req.Name != "" {
+		update["$set"].(bson.M)["name"] = utils.SanitizeString(req.Name)
+	}
+	if req.JobType != nil {
+		update["$set"].(bson.M)["job_type"] = *req.JobType
+	}
+	if req.Location != nil {
+		update["$set"].(bson.M)["location"] = strings.TrimSpace(utils.SanitizeString(*req.Location))
+	}
+	if req.Keywords != nil {
+		update["$set"].(bson.M)["keywords"] = cleanTerms(req.Keywords)
+	}
+	if req.Skills != nil {
+		update["$set"].(bson.M)["skills"] = cleanTerms(req.Skills)
+	}
+	if req.Frequency != "" {
+		update["$set"].(bson.M)["frequency"] = req.Frequency
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var search models.SavedJobSearch
+	err = config.GetCollection("saved_job_searches").FindOneAndUpdate(ctx, bson.M{
+		"_id":     searchID,
+		"user_id": userID,
+	}, update, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&search)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "Saved search not found",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"error":   false,
+		"message": "Saved search updated successfully",
+		"data":    search,
+	})
+}
+
+func (h *JobHandler) DeleteSavedSearch(c *fiber.Ctx) error {
+	userID := middleware.GetUserID(c)
+	searchID, err := primitive.ObjectIDFromHex(c.Params("searchId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid saved search ID",
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	result, err := config.GetCollection("saved_job_searches").DeleteOne(ctx, bson.M{
+		"_id":     searchID,
+		"user_id": userID,
+	})
+	if err != nil || result.DeletedCount == 0 {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "Saved search not found",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"error":   false,
+		"message": "Saved search deleted successfully",
+	})
+}
+
+// notifySavedSearchMatches alerts users with an instant saved search that
+// matches a new job. Users with several matching searches are alerted once.
+// Daily searches are picked up by SendJobAlertDigests.
+func (h *JobHandler) notifySavedSearchMatches(job models.Job) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	cursor, err := config.GetCollection("saved_job_searches").Find(ctx, bson.M{
+		"frequency": models.AlertInstant,
+		"user_id":   bson.M{"$ne": job.PostedBy},
+	})
+	if err != nil {
+		return
+	}
+	defer cursor.Close(ctx)
+
+	var searches []models.SavedJobSearch
+	if err = cursor.All(ctx, &searches); err != nil {
+		return
+	}
+
+	userIDs := []primitive.ObjectID{}
+	matched := map[primitive.ObjectID]bool{}
+	for i := range searches {
+		if !matched[searches[i].UserID] && searches[i].Matches(&job) {
+			matched[searches[i].UserID] = true
+			userIDs = append(userIDs, searches[i].UserID)
+		}
+	}
+	if len(userIDs) == 0 {
+		return
+	}
+
+	usersCollection := config.GetCollection("users")
+
+	// Get job poster info
+	var poster models.User
+	usersCollection.FindOne(ctx, bson.M{"_id": job.PostedBy}).Decode(&poster)
+
+	cursor, err = usersCollection.Find(ctx, bson.M{
+		"_id":         bson.M{"$in": userIDs},
+		"is_verified": true,
+		"is_active":   true,
+	})
+	if err != nil {
+		return
+	}
+	defer cursor.Close(ctx)
+
+	var users []models.User
+	cursor.All(ctx, &users)
+
+	for _, user := range users {
+		createNotification(ctx, user.ID, "New Job Matches Your Search",
+			"A new "+job.Title+" position has been posted at "+job.Company,
+			models.NotificationJobPosted, &job.ID, "job")
+
+		emailSubject := "New Job Opportunity on AlmaniPortal"
+		h.emailService.SendJobNotification(user.Email, job.Title, job.Company, poster.Name, emailSubject, "")
+	}
+}
+
+// SendJobAlertDigests periodically emails users with daily saved searches
+// the jobs posted since their last digest
+func SendJobAlertDigests() {
+	ticker := time.NewTicker(1 * time.Hour)
+	defer ticker.Stop()
+
+	emailService := utils.NewEmailService()
+	for {
+		sendDueJobDigests(emailService)
+		<-ticker.C
+	}
+}
+
+func sendDueJobDigests(emailService *utils.EmailService) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	now := time.Now()
+	searchesCollection := config.GetCollection("saved_job_searches")
+	cursor, err := searchesCollection.Find(ctx, bson.M{
+		"frequency": models.AlertDaily,
+		"$or": []bson.M{
+			{"last_digest_at": nil},
+			{"last_digest_at": bson.M{"$lte": now.Add(-24 * time.Hour)}},
+		},
+	})
+	if err != nil {
+		log.Printf("Failed to fetch saved searches for digests: %v", err)
+		return
+	}
+
+	var searches []models.SavedJobSearch
+	err = cursor.All(ctx, &searches)
+	cursor.Close(ctx)
+	if err != nil {
+		log.Printf("Failed to decode saved searches for digests: %v", err)
+		return
+	}
+	if len(searches) == 0 {
+		return
+	}
+
+	// Each search covers jobs posted since its last digest, or since it was saved
+	since := func(search *models.SavedJobSearch) time.Time {
+		if search.LastDigestAt != nil {
+			return *search.LastDigestAt
+		}
+		return search.CreatedAt
+	}
+	earliest := now
+	for i := range searches {
+		if s := since(&searches[i]); s.Before(earliest) {
+			earliest = s
+		}
+	}
+
+	filter := visibleJobFilter()
+	filter["created_at"] = bson.M{"$gt": earliest}
+	cursor, err = config.GetCollection("jobs").Find(ctx, filter, options.Find().SetSort(bson.M{"created_at": -1}))
+	if err != nil {
+		log.Printf("Failed to fetch jobs for digests: %v", err)
+		return
+	}
+
+	var jobs []models.Job
+	err = cursor.All(ctx, &jobs)
+	cursor.Close(ctx)
+	if err != nil {
+		log.Printf("Failed to decode jobs for digests: %v", err)
+		return
+	}
+
+	// Group matches per user so each user gets one digest
+	digests := map[primitive.ObjectID][]models.Job{}
+	included := map[primitive.ObjectID]map[primitive.ObjectID]bool{}
+	searchIDs := []primitive.ObjectID{}
+	for i := range searches {
+		search := &searches[i]
+		searchIDs = append(searchIDs, search.ID)
+		if included[search.UserID] == nil {
+			included[search.UserID] = map[primitive.ObjectID]bool{}
+		}
+		for j := range jobs {
+			job := &jobs[j]
+			if job.PostedBy == search.UserID || !job.CreatedAt.After(since(search)) ||
+				included[search.UserID][job.ID] || !search.Matches(job) {
+				continue
+			}
+			included[search.UserID][job.ID] = true
+			digests[search.UserID] = append(digests[search.UserID], *job)
+		}
+	}
+
+	// Mark the searches first so a failing email is not resent every hour
+	searchesCollection.UpdateMany(ctx, bson.M{"_id": bson.M{"$in": searchIDs}}, bson.M{
+		"$set": bson.M{"last_digest_at": now},
+	})
+
+	sent := 0
+	usersCollection := config.GetCollection("users")
+	for userID, matches := range digests {
+		var user models.User
+		err := usersCollection.FindOne(ctx, bson.M{
+			"_id":         userID,
+			"is_verified": true,
+			"is_active":   true,
+		}).Decode(&user)
+		if err != nil {
+			continue
+		}
+
+		message := strconv.Itoa(len(matches)) + " new jobs match your saved searches"
+		if len(matches) == 1 {
+			message = matches[0].Title + " at " + matches[0].Company + " matches your saved searches"
+		}
+		createNotification(ctx, userID, "New Jobs Match Your Searches", message,
+			models.NotificationJobPosted, nil, "job")
+		emailService.SendJobAlertDigest(user.Email, user.Name, matches)
+		sent++
+	}
+
+	if sent > 0 {
+		log.Printf("Sent job alert digests to %d users", sent)
+	}
+}
@@ -0,0 +1,531 @@
+package handlers
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"ete-alumni-portal/config"
+	"ete-alumni-portal/middleware"
+	"ete-alumni-portal/models"
+	"ete-alumni-portal/utils"
+)
+
+type ShowcaseHandler struct{}
+
+func NewShowcaseHandler() *ShowcaseHandler {
+	return &ShowcaseHandler{}
+}
+
+// GetShowcases lists published showcases. It is public.
+func (h *ShowcaseHandler) GetShowcases(c *fiber.Ctx) error {
+	return h.listShowcases(c, bson.M{"is_active": true, "is_published": true})
+}
+
+// GetAllShowcases lists every showcase, including unpublished ones, for curators
+func (h *ShowcaseHandler) GetAllShowcases(c *fiber.Ctx) error {
+	return h.listShowcases(c, bson.M{"is_active": true})
+}
+
+func (h *ShowcaseHandler) listShowcases(c *fiber.Ctx, filter bson.M) error {
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	collection := config.GetCollection("project_showcases")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	total, err := collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to count showcases",
+		})
+	}
+
+	skip := (page - 1) * limit
+	opts := options.Find().
+		SetSkip(int64(skip)).
+		SetLimit(int64(limit)).
+		SetSort(bson.M{"updated_at": -1})
+
+	cursor, err := collection.Find(ctx, filter, opts)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to fetch showcases",
+		})
+	}
+	defer cursor.Close(ctx)
+
+	showcases := []models.Showcase{}
+	if err = cursor.All(ctx, &showcases); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to decode showcases",
+		})
+	}
+
+	for i := range showcases {
+		projects := showcaseProjects(ctx, showcases[i].ProjectIDs)
+		showcases[i].ProjectCount = len(projects)
+		if showcases[i].CoverImageURL == "" {
+			showcases[i].CoverImageURL = defaultShowcaseCover(projects)
+		}
+	}
+
+	return c.JSON(fiber.Map{
+		"error": false,
+		"data": fiber.Map{
+			"showcases": showcases,
+			"pagination": fiber.Map{
+				"page":        page,
+				"limit":       limit,
+				"total":       total,
+				"total_pages": (total + int64(limit) - 1) / int64(limit),
+			},
+		},
+	})
+}
+
+// GetShowcase returns a published showcase with its projects in order. It is
+// public, so authors are shown as an anonymous visitor would see them.
+func (h *ShowcaseHandler) GetShowcase(c *fiber.Ctx) error {
+	showcaseID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid showcase ID",
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var showcase models.Showcase
+	err = config.GetCollection("project_showcases").FindOne(ctx, bson.M{
+		"_id":          showcaseID,
+		"is_active":    true,
+		"is_published": true,
+	}).Decode(&showcase)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "Showcase not found",
+		})
+	}
+
+	showcase.Projects = showcaseProjects(ctx, showcase.ProjectIDs)
+	showcase.ProjectCount = len(showcase.Projects)
+	if showcase.CoverImageURL == "" {
+		showcase.CoverImageURL = defaultShowcaseCover(showcase.Projects)
+	}
+
+	// Populate author and team information
+	usersCollection := config.GetCollection("users")
+	for i := range showcase.Projects {
+		project := &showcase.Projects[i]
+
+		var user models.User
+		if err := usersCollection.FindOne(ctx, bson.M{"_id": project.AuthorID}).Decode(&user); err == nil {
+			project.Author = publicUserResponse(&user)
+		}
+
+		contributors := []models.ProjectContributor{}
+		for _, contributor := range project.Contributors {
+			if contributor.Status != models.ContributorAccepted {
+				continue
+			}
+			var member models.User
+			if err := usersCollection.FindOne(ctx, bson.M{"_id": contributor.UserID}).Decode(&member); err == nil {
+				contributor.User = publicUserResponse(&member)
+			}
+			contributors = append(contributors, contributor)
+		}
+		project.Contributors = contributors
+	}
+
+	return c.JSON(fiber.Map{
+		"error": false,
+		"data":  showcase,
+	})
+}
+
+func (h *ShowcaseHandler) CreateShowcase(c *fiber.Ctx) error {
+	userID := middleware.GetUserID(c)
+
+	var req models.CreateShowcaseRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid request body",
+		})
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": err.Error(),
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	projectIDs, err := resolveShowcaseProjects(ctx, req.ProjectIDs)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": err.Error(),
+		})
+	}
+
+	showcase := models.Showcase{
+		ID:            primitive.NewObjectID(),
+		Title:         utils.SanitizeString(req.Title),
+		Description:   utils.SanitizeString(req.Description),
+		CoverImageURL: req.CoverImageURL,
+		ProjectIDs:    projectIDs,
+		IsPublished:   req.IsPublished,
+		CreatedBy:     userID,
+		UpdatedBy:     userID,
+		IsActive:      true,
+		CreatedAt:     time.Now(),
+		UpdatedAt:     time.Now(),
+	}
+
+	_, err = config.GetCollection("project_showcases").InsertOne(ctx, showcase)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to create showcase",
+		})
+	}
+
+	showcase.ProjectCount = len(projectIDs)
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"error":   false,
+		"message": "Showcase created successfully",
+		"data":    showcase,
+	})
+}
+
+func (h *ShowcaseHandler) UpdateShowcase(c *fiber.Ctx) error {
+	userID := middleware.GetUserID(c)
+	showcaseID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid showcase ID",
+		})
+	}
+
+	var req models.UpdateShowcaseRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid request body",
+		})
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": err.Error(),
+		})
+	}
+
+	// Build update document
+	update := bson.M{
+		"$set": bson.M{
+			"updated_by": userID,
+			"updated_at": time.Now(),
+		},
+	}
+
+	if req.Title != "" {
+		update["$set"].(bson.M)["title"] = utils.SanitizeString(req.Title)
+	}
+	if req.Description != nil {
+		update["$set"].(bson.M)["description"] = utils.SanitizeString(*req.Description)
+	}
+	if req.CoverImageURL != nil {
+		update["$set"].(bson.M)["cover_image_url"] = *req.CoverImageURL
+	}
+	if req.IsPublished != nil {
+		update["$set"].(bson.M)["is_published"] = *req.IsPublished
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var showcase models.Showcase
+	err = config.GetCollection("project_showcases").FindOneAndUpdate(ctx, bson.M{
+		"_id":       showcaseID,
+		"is_active": true,
+	}, update, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&showcase)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "Showcase not found",
+		})
+	}
+
+	showcase.ProjectCount = len(showcase.ProjectIDs)
+
+	return c.JSON(fiber.Map{
+		"error":   false,
+		"message": "Showcase updated successfully",
+		"data":    showcase,
+	})
+}
+
+// SetShowcaseProjects replaces the showcase's projects in the given order
+func (h *ShowcaseHandler) SetShowcaseProjects(c *fiber.Ctx) error {
+	userID := middleware.GetUserID(c)
+	showcaseID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid showcase ID",
+		})
+	}
+
+	var req models.SetShowcaseProjectsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid request body",
+		})
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": err.Error(),
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	projectIDs, err := resolveShowcaseProjects(ctx, req.ProjectIDs)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": err.Error(),
+		})
+	}
+
+	var showcase models.Showcase
+	err = config.GetCollection("project_showcases").FindOneAndUpdate(ctx, bson.M{
+		"_id":       showcaseID,
+		"is_active": true,
+	}, bson.M{
+		"$set": bson.M{
+			"project_ids": projectIDs,
+			"updated_by":  userID,
+			"updated_at":  time.Now(),
+		},
+	}, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&showcase)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "Showcase not found",
+		})
+	}
+
+	showcase.ProjectCount = len(showcase.ProjectIDs)
+
+	return c.JSON(fiber.Map{
+		"error":   false,
+		"message": "Showcase projects updated successfully",
+		"data":    showcase,
+	})
+}
+
+func (h *ShowcaseHandler) DeleteShowcase(c *fiber.Ctx) error {
+	showcaseID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid showcase ID",
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	result, err := config.GetCollection("project_showcases").UpdateOne(ctx, bson.M{
+		"_id":       showcaseID,
+		"is_active": true,
+	}, bson.M{
+		"$set": bson.M{
+			"is_active":  false,
+			"updated_at": time.Now(),
+		},
+	})
+	if err != nil || result.MatchedCount == 0 {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "Showcase not found",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"error":   false,
+		"message": "Showcase deleted successfully",
+	})
+}
+
+// FeatureProject sets or clears a project's featured flag. Only approved
+// projects can be featured.
+func (h *ProjectHandler) FeatureProject(c *fiber.Ctx) error {
+	projectID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid project ID",
+		})
+	}
+
+	var req models.FeatureProjectRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid request body",
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{
+		"_id":       projectID,
+		"is_active": true,
+	}
+	if req.IsFeatured {
+		filter["status"] = approvedProjectStatus()
+	}
+
+	result, err := config.GetCollection("projects").UpdateOne(ctx, filter, bson.M{
+		"$set": bson.M{"is_featured": req.IsFeatured},
+	})
+	if err != nil || result.MatchedCount == 0 {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "Project not found or not approved",
+		})
+	}
+
+	message := "Project featured successfully"
+	if !req.IsFeatured {
+		message = "Project unfeatured successfully"
+	}
+
+	return c.JSON(fiber.Map{
+		"error":   false,
+		"message": message,
+	})
+}
+
+// resolveShowcaseProjects validates project IDs, keeping their order and
+// dropping duplicates. Every project must be active and approved.
+func resolveShowcaseProjects(ctx context.Context, ids []string) ([]primitive.ObjectID, error) {
+	projectIDs := []primitive.ObjectID{}
+	seen := map[primitive.ObjectID]bool{}
+	for _, id := range ids {
+		objID, err := primitive.ObjectIDFromHex(id)
+		if err != nil {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "Invalid project ID: "+id)
+		}
+		if seen[objID] {
+			continue
+		}
+		seen[objID] = true
+		projectIDs = append(projectIDs, objID)
+	}
+
+	if len(projectIDs) == 0 {
+		return projectIDs, nil
+	}
+
+	count, err := config.GetCollection("projects").CountDocuments(ctx, bson.M{
+		"_id":       bson.M{"$in": projectIDs},
+		"is_active": true,
+		"status":    approvedProjectStatus(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if int(count) != len(projectIDs) {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Showcases can only include approved projects")
+	}
+
+	return projectIDs, nil
+}
+
+// showcaseProjects loads the showcase's projects in order, skipping any that
+// have since been deleted or unpublished
+func showcaseProjects(ctx context.Context, projectIDs []primitive.ObjectID) []models.Project {
+	projects := []models.Project{}
+	if len(projectIDs) == 0 {
+		return projects
+	}
+
+	cursor, err := config.GetCollection("projects").Find(ctx, bson.M{
+		"_id":       bson.M{"$in": projectIDs},
+		"is_active": true,
+		"status":    approvedProjectStatus(),
+	})
+	if err != nil {
+		return projects
+	}
+	defer cursor.Close(ctx)
+
+	var found []models.Project
+	if err := cursor.All(ctx, &found); err != nil {
+		return projects
+	}
+
+	byID := map[primitive.ObjectID]models.Project{}
+	for _, project := range found {
+		byID[project.ID] = project
+	}
+	for _, id := range projectIDs {
+		if project, ok := byID[id]; ok {
+			projects = append(projects, project)
+		}
+	}
+	return projects
+}
+
+// defaultShowcaseCover uses the first project cover when none was chosen
+func defaultShowcaseCover(projects []models.Project) string {
+	for _, project := range projects {
+		if project.CoverImageURL != "" {
+			return project.CoverImageURL
+		}
+	}
+	return ""
+}
+
+// publicUserResponse is a user as shown to visitors who are not logged in
+func publicUserResponse(user *models.User) *models.UserResponse {
+	response := user.ToResponseFor(primitive.NilObjectID, false)
+	response.Email = ""
+	return response
+}
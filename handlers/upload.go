@@ -229,7 +229,7 @@ func (h *UploadHandler) validateImageFile(file *multipart.FileHeader) error {
 
 // Ensure upload directories exist
 func init() {
-	dirs := []string{"./public/avatars", "./public/gallery", "./public/banners"}
+	dirs := []string{"./public/avatars", "./public/gallery", "./public/banners", resumeDir, projectMediaDir}
 	for _, dir := range dirs {
 		if err := os.MkdirAll(dir, 0755); err != nil {
 			log.Printf("Failed to create directory %s: %v", dir, err)
@@ -38,9 +38,18 @@ func (h *UserHandler) GetProfile(c *fiber.Ctx) error {
 		})
 	}
 
+	completeness := profileCompleteness(ctx, &user)
+	response := user.ToResponse()
+	response.Privacy = &user.Privacy
+	response.DeletionScheduledAt = user.DeletionScheduledAt
+	response.Completeness = &completeness
+	if endorsements, err := getSkillEndorsements(ctx, user.ID); err == nil {
+		response.SkillEndorsements = endorsements
+	}
+
 	return c.JSON(fiber.Map{
 		"error": false,
-		"data":  user.ToResponse(),
+		"data":  response,
 	})
 }
 
@@ -84,6 +93,21 @@ func (h *UserHandler) UpdateProfile(c *fiber.Ctx) error {
 	if req.Experience != "" {
 		update["$set"].(bson.M)["experience"] = utils.SanitizeString(req.Experience)
 	}
+	if req.Bio != "" {
+		update["$set"].(bson.M)["bio"] = utils.SanitizeString(req.Bio)
+	}
+	if req.GraduationYear != 0 {
+		update["$set"].(bson.M)["graduation_year"] = req.GraduationYear
+	}
+	// Student ID and CGPA only apply to students
+	if middleware.GetUserRole(c) == models.RoleStudent {
+		if req.StudentID != "" {
+			update["$set"].(bson.M)["student_id"] = utils.SanitizeString(req.StudentID)
+		}
+		if req.CGPA != 0 {
+			update["$set"].(bson.M)["cgpa"] = req.CGPA
+		}
+	}
 	if req.Skills != nil {
 		update["$set"].(bson.M)["skills"] = req.Skills
 	}
@@ -93,6 +117,23 @@ func (h *UserHandler) UpdateProfile(c *fiber.Ctx) error {
 	if req.LinkedInURL != "" {
 		update["$set"].(bson.M)["linkedin_url"] = req.LinkedInURL
 	}
+	if req.Privacy != nil {
+		if req.Privacy.ProfileVisibility != "" {
+			update["$set"].(bson.M)["privacy.profile_visibility"] = req.Privacy.ProfileVisibility
+		}
+		if req.Privacy.EmailVisibility != "" {
+			update["$set"].(bson.M)["privacy.email_visibility"] = req.Privacy.EmailVisibility
+		}
+		if req.Privacy.ContactVisibility != "" {
+			update["$set"].(bson.M)["privacy.contact_visibility"] = req.Privacy.ContactVisibility
+		}
+		if req.Privacy.AllowMessagesFrom != "" {
+			update["$set"].(bson.M)["privacy.allow_messages_from"] = req.Privacy.AllowMessagesFrom
+		}
+		if req.Privacy.HideProfileViews != nil {
+			update["$set"].(bson.M)["privacy.hide_profile_views"] = *req.Privacy.HideProfileViews
+		}
+	}
 
 	collection := config.GetCollection("users")
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
@@ -112,20 +153,28 @@ func (h *UserHandler) UpdateProfile(c *fiber.Ctx) error {
 		})
 	}
 
+	completeness := profileCompleteness(ctx, &user)
+	response := user.ToResponse()
+	response.Privacy = &user.Privacy
+	response.Completeness = &completeness
+
 	return c.JSON(fiber.Map{
 		"error":   false,
 		"message": "Profile updated successfully",
-		"data":    user.ToResponse(),
+		"data":    response,
 	})
 }
 
 func (h *UserHandler) GetUsers(c *fiber.Ctx) error {
+	viewerID := middleware.GetUserID(c)
+
 	// Parse query parameters
 	page, _ := strconv.Atoi(c.Query("page", "1"))
 	limit, _ := strconv.Atoi(c.Query("limit", "20"))
 	role := c.Query("role")
 	search := c.Query("search")
 	graduationYear := c.Query("graduation_year")
+	sortBy := c.Query("sort")
 
 	if page < 1 {
 		page = 1
@@ -178,6 +227,11 @@ func (h *UserHandler) GetUsers(c *fiber.Ctx) error {
 		SetLimit(int64(limit)).
 		SetSort(bson.M{"name": 1})
 
+	// Rank by endorsements received when requested
+	if sortBy == "endorsements" {
+		opts.SetSort(bson.D{{Key: "endorsements_count", Value: -1}, {Key: "name", Value: 1}})
+	}
+
 	cursor, err := collection.Find(ctx, filter, opts)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
@@ -195,10 +249,11 @@ func (h *UserHandler) GetUsers(c *fiber.Ctx) error {
 		})
 	}
 
-	// Convert to response format
+	// Convert to response format, applying each user's privacy settings
+	connected, _ := connectedUserIDs(ctx, viewerID)
 	var userResponses []*models.UserResponse
 	for _, user := range users {
-		userResponses = append(userResponses, user.ToResponse())
+		userResponses = append(userResponses, user.ToResponseFor(viewerID, connected[user.ID]))
 	}
 
 	return c.JSON(fiber.Map{
@@ -216,6 +271,7 @@ func (h *UserHandler) GetUsers(c *fiber.Ctx) error {
 }
 
 func (h *UserHandler) GetUserByID(c *fiber.Ctx) error {
+	viewerID := middleware.GetUserID(c)
 	userIDStr := c.Params("id")
 	userID, err := primitive.ObjectIDFromHex(userIDStr)
 	if err != nil {
@@ -242,9 +298,22 @@ func (h *UserHandler) GetUserByID(c *fiber.Ctx) error {
 		})
 	}
 
+	if viewerID != user.ID {
+		recordProfileView(ctx, viewerID, user.ID)
+	}
+
+	relationship := getRelationship(ctx, viewerID, user.ID)
+	response := user.ToResponseFor(viewerID, relationship.Status == models.RelationshipConnected)
+	response.Relationship = &relationship
+	if !response.ProfileRestricted {
+		if endorsements, err := getSkillEndorsements(ctx, user.ID); err == nil {
+			response.SkillEndorsements = endorsements
+		}
+	}
+
 	return c.JSON(fiber.Map{
 		"error": false,
-		"data":  user.ToResponse(),
+		"data":  response,
 	})
 }
 
@@ -262,17 +331,18 @@ func (h *UserHandler) GetDashboardStats(c *fiber.Ctx) error {
 		// Projects count
 		projectsCollection := config.GetCollection("projects")
 		projectsCount, _ := projectsCollection.CountDocuments(ctx, bson.M{
-			"author_id": userID,
 			"is_active": true,
+			"$and":      []bson.M{projectMemberFilter(userID)},
 		})
 		stats["projects_count"] = projectsCount
 
-		// Job interests count
-		jobInterestsCollection := config.GetCollection("job_interests")
-		jobInterestsCount, _ := jobInterestsCollection.CountDocuments(ctx, bson.M{
+		// Job applications count, kept under the old key for existing clients
+		jobApplicationsCollection := config.GetCollection("job_applications")
+		jobApplicationsCount, _ := jobApplicationsCollection.CountDocuments(ctx, bson.M{
 			"user_id": userID,
+			"status":  bson.M{"$ne": models.ApplicationWithdrawn},
 		})
-		stats["job_interests_count"] = jobInterestsCount
+		stats["job_interests_count"] = jobApplicationsCount
 
 	case models.RoleAlumni:
 		// Jobs posted count
@@ -359,3 +429,73 @@ func (h *UserHandler) GetDashboardStats(c *fiber.Ctx) error {
 		"data":  stats,
 	})
 }
+
+// GetEmailPreferences returns which optional emails the user receives. Both
+// default to on until the user changes them.
+func (h *UserHandler) GetEmailPreferences(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	prefs := models.UserPreferences{}
+	prefs.EmailNotifications.Messages = true
+	prefs.EmailNotifications.ProfileNudges = true
+	config.GetCollection("user_preferences").FindOne(ctx, bson.M{"user_id": middleware.GetUserID(c)}).Decode(&prefs)
+
+	return c.JSON(fiber.Map{
+		"error": false,
+		"data":  prefs.EmailNotifications,
+	})
+}
+
+func (h *UserHandler) UpdateEmailPreferences(c *fiber.Ctx) error {
+	userID := middleware.GetUserID(c)
+
+	var req models.UpdateEmailPreferencesRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid request body",
+		})
+	}
+
+	// Preferences left out keep their value, or the default on first save
+	set := bson.M{}
+	setOnInsert := bson.M{"_id": primitive.NewObjectID()}
+	for field, value := range map[string]*bool{
+		"messages":       req.Messages,
+		"profile_nudges": req.ProfileNudges,
+	} {
+		if value != nil {
+			set["email_notifications."+field] = *value
+		} else {
+			setOnInsert["email_notifications."+field] = true
+		}
+	}
+
+	update := bson.M{"$setOnInsert": setOnInsert}
+	if len(set) > 0 {
+		update["$set"] = set
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var prefs models.UserPreferences
+	err := config.GetCollection("user_preferences").FindOneAndUpdate(ctx,
+		bson.M{"user_id": userID},
+		update,
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+	).Decode(&prefs)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to update email preferences",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"error":   false,
+		"message": "Email preferences updated successfully",
+		"data":    prefs.EmailNotifications,
+	})
+}
@@ -93,24 +93,66 @@ import (
 	"ete-alumni-portal/routes"
 )
 
+// Request body limits; only the upload routes accept large bodies
+const (
+	maxRequestBody = 4 * 1024 * 1024
+	maxUploadBody  = 64 * 1024 * 1024
+)
+
 func main() {
 	// Load environment variables
 	if err := godotenv.Load(); err != nil {
 		log.Println("No .env file found")
 	}
 
+	// A guessable salary hash secret would let anyone with the database
+	// link anonymous salary reports back to their authors
+	if cfg := config.GetConfig(); cfg.SalaryHashSecret == "" && cfg.Environment != "test" {
+		log.Fatal("SALARY_HASH_SECRET must be set")
+	}
+
 	// Initialize database connection
 	config.ConnectDB()
 
+	// Move one-click job interests into applications
+	handlers.MigrateJobInterests()
+
+	// Move project attachments out of the public directory
+	handlers.MigrateProjectMedia()
+
 	// Start rate limit cleanup goroutine
 	go middleware.CleanupRateLimits()
 
+	// Start account deletion worker
+	go handlers.ProcessAccountDeletions()
+
+	// Start profile completeness nudges
+	go handlers.SendProfileNudges()
+
+	// Start repository metadata refresh
+	go handlers.RefreshRepoMetadata()
+
+	// Start project ranking refresh
+	go handlers.RefreshProjectRankings()
+
+	// Start job expiry reminders and deactivation
+	go handlers.ProcessJobExpiry()
+
+	// Start daily saved job search digests
+	go handlers.SendJobAlertDigests()
+
 	// Initialize WebSocket manager
 	log.Println("Starting WebSocket manager...")
 	go handlers.WSManager.Run()
 
 	// Create Fiber app
 	app := fiber.New(fiber.Config{
+		// Sized for project video uploads; bodies are streamed so that
+		// middleware.BodyLimit can hold every other route to a much smaller
+		// limit before the body, including multipart uploads, is read
+		BodyLimit:                    maxUploadBody,
+		StreamRequestBody:            true,
+		DisablePreParseMultipartForm: true,
 		ErrorHandler: func(c *fiber.Ctx, err error) error {
 			code := fiber.StatusInternalServerError
 			if e, ok := err.(*fiber.Error); ok {
@@ -126,6 +168,7 @@ func main() {
 	// Middleware
 	app.Use(logger.New())
 	app.Use(recover.New())
+	app.Use(middleware.BodyLimit(maxRequestBody, maxUploadBody, "/upload/"))
 	app.Use(cors.New(cors.Config{
 		AllowOrigins:     "http://localhost:3000",
 		AllowHeaders:     "Origin, Content-Type, Accept, Authorization",
@@ -38,6 +38,25 @@ func AuthRequired() fiber.Handler {
 	}
 }
 
+// OptionalAuth identifies the user when a valid token is sent and lets other
+// requests through anonymously, with no user ID and an empty role
+func OptionalAuth() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		c.Locals("userRole", models.UserRole(""))
+
+		if authHeader := c.Get("Authorization"); authHeader != "" {
+			claims, err := utils.ValidateToken(strings.Replace(authHeader, "Bearer ", "", 1))
+			if err == nil {
+				c.Locals("userID", claims.UserID)
+				c.Locals("userEmail", claims.Email)
+				c.Locals("userRole", claims.Role)
+			}
+		}
+
+		return c.Next()
+	}
+}
+
 func RoleRequired(roles ...models.UserRole) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		userRole := c.Locals("userRole").(models.UserRole)
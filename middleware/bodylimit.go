@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// BodyLimit caps request bodies at limit, or at uploadLimit for paths under
+// uploadPrefix. The server streams request bodies, so oversized requests are
+// refused from their Content-Length before the body is read.
+func BodyLimit(limit, uploadLimit int, uploadPrefix string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		allowed := limit
+		if strings.HasPrefix(c.Path(), uploadPrefix) {
+			allowed = uploadLimit
+		}
+
+		// Chunked bodies have no declared length and cannot be checked up front
+		length := c.Request().Header.ContentLength()
+		if length > allowed || length == -1 {
+			return c.Status(fiber.StatusRequestEntityTooLarge).JSON(fiber.Map{
+				"error":   true,
+				"message": "Request body too large",
+			})
+		}
+
+		return c.Next()
+	}
+}
@@ -0,0 +1,58 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Cohort is a graduating batch, identified by graduation year
+type Cohort struct {
+	Year        int `json:"year" bson:"_id"`
+	MemberCount int `json:"member_count" bson:"member_count"`
+}
+
+type CohortCount struct {
+	Name  string `json:"name" bson:"_id"`
+	Count int    `json:"count" bson:"count"`
+}
+
+type CohortStats struct {
+	Year            int             `json:"year"`
+	MemberCount     int             `json:"member_count"`
+	RoleCounts      []CohortCount   `json:"role_counts"`
+	Companies       []CohortCount   `json:"companies"`
+	Locations       []CohortCount   `json:"locations"`
+	TopSkills       []CohortCount   `json:"top_skills"`
+	Representatives []*UserResponse `json:"representatives"`
+}
+
+// CohortRepresentative is a member appointed by an admin to post cohort updates
+type CohortRepresentative struct {
+	ID         primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	Year       int                `json:"year" bson:"year"`
+	UserID     primitive.ObjectID `json:"user_id" bson:"user_id"`
+	AssignedBy primitive.ObjectID `json:"assigned_by" bson:"assigned_by"`
+	CreatedAt  time.Time          `json:"created_at" bson:"created_at"`
+}
+
+type AssignRepresentativeRequest struct {
+	UserID string `json:"user_id" validate:"required"`
+}
+
+type CohortAnnouncement struct {
+	ID        primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	Year      int                `json:"year" bson:"year"`
+	AuthorID  primitive.ObjectID `json:"author_id" bson:"author_id"`
+	Author    *UserResponse      `json:"author,omitempty" bson:"-"`
+	Title     string             `json:"title" bson:"title"`
+	Content   string             `json:"content" bson:"content"`
+	IsActive  bool               `json:"is_active" bson:"is_active"`
+	CreatedAt time.Time          `json:"created_at" bson:"created_at"`
+	UpdatedAt time.Time          `json:"updated_at" bson:"updated_at"`
+}
+
+type CreateCohortAnnouncementRequest struct {
+	Title   string `json:"title" validate:"required,min=3,max=200"`
+	Content string `json:"content" validate:"required,min=1,max=5000"`
+}
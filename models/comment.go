@@ -0,0 +1,96 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type CommentStatus string
+
+const (
+	CommentVisible CommentStatus = "visible"
+	CommentHidden  CommentStatus = "hidden"
+	CommentDeleted CommentStatus = "deleted"
+)
+
+// CommentHider records who hid a comment; only admins can restore comments
+// they or the report threshold hid.
+type CommentHider string
+
+const (
+	HiddenByOwner   CommentHider = "owner"
+	HiddenByAdmin   CommentHider = "admin"
+	HiddenByReports CommentHider = "reports"
+)
+
+// CommentReportThreshold is the number of reports after which a comment is
+// hidden automatically until a moderator reviews it.
+const CommentReportThreshold = 3
+
+// ProjectComment is a comment on a project. Replies point at their parent
+// comment; top-level comments have no ParentID.
+type ProjectComment struct {
+	ID           primitive.ObjectID   `json:"id" bson:"_id,omitempty"`
+	ProjectID    primitive.ObjectID   `json:"project_id" bson:"project_id"`
+	AuthorID     primitive.ObjectID   `json:"author_id" bson:"author_id"`
+	Author       *UserResponse        `json:"author,omitempty" bson:"-"`
+	ParentID     *primitive.ObjectID  `json:"parent_id,omitempty" bson:"parent_id,omitempty"`
+	Content      string               `json:"content" bson:"content"`
+	MentionIDs   []primitive.ObjectID `json:"mention_ids,omitempty" bson:"mention_ids,omitempty"`
+	Status       CommentStatus        `json:"status" bson:"status"`
+	RepliesCount int                  `json:"replies_count" bson:"replies_count"`
+	ReportsCount int                  `json:"-" bson:"reports_count"`
+	IsEdited     bool                 `json:"is_edited" bson:"is_edited"`
+	ModeratedBy  *primitive.ObjectID  `json:"-" bson:"moderated_by,omitempty"`
+	HiddenBy     CommentHider         `json:"hidden_by,omitempty" bson:"hidden_by,omitempty"`
+	CreatedAt    time.Time            `json:"created_at" bson:"created_at"`
+	UpdatedAt    time.Time            `json:"updated_at" bson:"updated_at"`
+}
+
+type CreateCommentRequest struct {
+	Content    string   `json:"content" validate:"required,min=1,max=2000"`
+	ParentID   string   `json:"parent_id,omitempty"`
+	MentionIDs []string `json:"mention_ids,omitempty" validate:"omitempty,max=10"`
+}
+
+type UpdateCommentRequest struct {
+	Content    string   `json:"content" validate:"required,min=1,max=2000"`
+	MentionIDs []string `json:"mention_ids,omitempty" validate:"omitempty,max=10"`
+}
+
+type CommentReportReason string
+
+const (
+	ReportSpam          CommentReportReason = "spam"
+	ReportHarassment    CommentReportReason = "harassment"
+	ReportInappropriate CommentReportReason = "inappropriate"
+	ReportOther         CommentReportReason = "other"
+)
+
+type CommentReport struct {
+	ID         primitive.ObjectID  `json:"id" bson:"_id,omitempty"`
+	CommentID  primitive.ObjectID  `json:"comment_id" bson:"comment_id"`
+	ProjectID  primitive.ObjectID  `json:"project_id" bson:"project_id"`
+	ReporterID primitive.ObjectID  `json:"reporter_id" bson:"reporter_id"`
+	Reason     CommentReportReason `json:"reason" bson:"reason"`
+	Details    string              `json:"details,omitempty" bson:"details,omitempty"`
+	CreatedAt  time.Time           `json:"created_at" bson:"created_at"`
+	ResolvedBy *primitive.ObjectID `json:"resolved_by,omitempty" bson:"resolved_by,omitempty"`
+	ResolvedAt *time.Time          `json:"resolved_at,omitempty" bson:"resolved_at,omitempty"`
+}
+
+type ReportCommentRequest struct {
+	Reason  CommentReportReason `json:"reason" validate:"required,oneof=spam harassment inappropriate other"`
+	Details string              `json:"details,omitempty" validate:"omitempty,max=500"`
+}
+
+type ModerateCommentRequest struct {
+	Action string `json:"action" validate:"required,oneof=hide restore"`
+}
+
+// ReportedComment is a comment awaiting moderation along with its reports
+type ReportedComment struct {
+	Comment ProjectComment  `json:"comment"`
+	Reports []CommentReport `json:"reports"`
+}
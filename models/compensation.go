@@ -0,0 +1,144 @@
+package models
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type CompensationPeriod string
+
+const (
+	PeriodHourly  CompensationPeriod = "hourly"
+	PeriodMonthly CompensationPeriod = "monthly"
+	PeriodYearly  CompensationPeriod = "yearly"
+)
+
+// hoursPerYear converts hourly pay to a yearly amount (40 hours x 52 weeks)
+const hoursPerYear = 2080
+
+// Compensation is structured pay for a job or salary report. Amounts are in
+// whole units of Currency per Period, capped at one billion so converting
+// them to a yearly amount cannot overflow.
+type Compensation struct {
+	Min       int64              `json:"min" bson:"min" validate:"gte=0,lte=1000000000"`
+	Max       int64              `json:"max" bson:"max" validate:"gtefield=Min,lte=1000000000"`
+	Currency  string             `json:"currency" bson:"currency" validate:"required,len=3,alpha"`
+	Period    CompensationPeriod `json:"period" bson:"period" validate:"required,oneof=hourly monthly yearly"`
+	HasEquity bool               `json:"has_equity" bson:"has_equity"`
+}
+
+// Annual converts an amount paid per period to a yearly amount
+func (p CompensationPeriod) Annual(amount int64) int64 {
+	switch p {
+	case PeriodHourly:
+		return amount * hoursPerYear
+	case PeriodMonthly:
+		return amount * 12
+	}
+	return amount
+}
+
+// String formats the compensation for the free text SalaryRange field, e.g.
+// "INR 600000 - 900000 per year + equity"
+func (c *Compensation) String() string {
+	amount := strconv.FormatInt(c.Min, 10)
+	if c.Max != c.Min {
+		amount += " - " + strconv.FormatInt(c.Max, 10)
+	}
+
+	unit := map[CompensationPeriod]string{
+		PeriodHourly:  "hour",
+		PeriodMonthly: "month",
+		PeriodYearly:  "year",
+	}[c.Period]
+
+	text := strings.ToUpper(c.Currency) + " " + amount + " per " + unit
+	if c.HasEquity {
+		text += " + equity"
+	}
+	return text
+}
+
+// ExperienceBand groups years of experience for salary insights
+func ExperienceBand(years int) string {
+	switch {
+	case years <= 2:
+		return "0-2"
+	case years <= 5:
+		return "3-5"
+	case years <= 10:
+		return "6-10"
+	}
+	return "10+"
+}
+
+// SalaryReport is compensation an alumnus shared anonymously. The submitter
+// is only stored as a keyed hash so reports can be updated or removed by
+// their author but not traced back to them.
+type SalaryReport struct {
+	ID              primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	SubmitterHash   string             `json:"-" bson:"submitter_hash"`
+	Company         string             `json:"company" bson:"company"`
+	CompanyKey      string             `json:"-" bson:"company_key"`
+	Role            string             `json:"role" bson:"role"`
+	RoleKey         string             `json:"-" bson:"role_key"`
+	ExperienceYears int                `json:"experience_years" bson:"experience_years"`
+	ExperienceBand  string             `json:"experience_band" bson:"experience_band"`
+	Compensation    Compensation       `json:"compensation" bson:"compensation"`
+	AnnualAmount    int64              `json:"annual_amount" bson:"annual_amount"`
+	CreatedAt       time.Time          `json:"created_at" bson:"created_at"`
+	UpdatedAt       time.Time          `json:"updated_at" bson:"updated_at"`
+}
+
+// SubmitSalaryReportRequest reports pay for a single amount, so Min and Max
+// of the compensation are the same
+type SubmitSalaryReportRequest struct {
+	Company         string             `json:"company" validate:"required,min=2,max=100"`
+	Role            string             `json:"role" validate:"required,min=2,max=100"`
+	ExperienceYears int                `json:"experience_years" validate:"gte=0,lte=50"`
+	Amount          int64              `json:"amount" validate:"required,gt=0,lte=1000000000"`
+	Currency        string             `json:"currency" validate:"required,len=3,alpha"`
+	Period          CompensationPeriod `json:"period" validate:"required,oneof=hourly monthly yearly"`
+	HasEquity       bool               `json:"has_equity"`
+}
+
+// SalaryInsight aggregates the yearly pay of one group of reports. Report
+// counts are given as a band and no average is published, so comparing
+// groups cannot single out a report.
+type SalaryInsight struct {
+	Company        string  `json:"company,omitempty"`
+	Role           string  `json:"role,omitempty"`
+	ExperienceBand string  `json:"experience_band,omitempty"`
+	Currency       string  `json:"currency"`
+	Reports        string  `json:"reports"`
+	Median         int64   `json:"median"`
+	Percentile25   int64   `json:"percentile_25"`
+	Percentile75   int64   `json:"percentile_75"`
+	EquityShare    float64 `json:"equity_share"`
+}
+
+// ReportCountBand buckets a group's report count for publication
+func ReportCountBand(count int) string {
+	switch {
+	case count < 10:
+		return "5-9"
+	case count < 20:
+		return "10-19"
+	case count < 50:
+		return "20-49"
+	}
+	return "50+"
+}
+
+// Percentile interpolates the p-th percentile of sorted, non-empty amounts
+func Percentile(sorted []int64, p float64) float64 {
+	position := p * float64(len(sorted)-1)
+	lower := int(math.Floor(position))
+	upper := int(math.Ceil(position))
+	weight := position - float64(lower)
+	return float64(sorted[lower])*(1-weight) + float64(sorted[upper])*weight
+}
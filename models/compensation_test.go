@@ -0,0 +1,48 @@
+package models
+
+import "testing"
+
+func TestReportCountBand(t *testing.T) {
+	cases := []struct {
+		count int
+		want  string
+	}{
+		{5, "5-9"},
+		{9, "5-9"},
+		{10, "10-19"},
+		{19, "10-19"},
+		{20, "20-49"},
+		{49, "20-49"},
+		{50, "50+"},
+		{1200, "50+"},
+	}
+
+	for _, tc := range cases {
+		if got := ReportCountBand(tc.count); got != tc.want {
+			t.Errorf("ReportCountBand(%d) = %q, want %q", tc.count, got, tc.want)
+		}
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	cases := []struct {
+		amounts []int64
+		p       float64
+		want    float64
+	}{
+		{[]int64{100}, 0.5, 100},
+		{[]int64{100, 200}, 0.5, 150},
+		{[]int64{100, 200, 300, 400, 500}, 0.5, 300},
+		{[]int64{100, 200, 300, 400, 500}, 0.25, 200},
+		{[]int64{100, 200, 300, 400, 500}, 0.75, 400},
+		{[]int64{100, 200, 300, 400}, 0.25, 175},
+		{[]int64{100, 200, 300, 400}, 0, 100},
+		{[]int64{100, 200, 300, 400}, 1, 400},
+	}
+
+	for _, tc := range cases {
+		if got := Percentile(tc.amounts, tc.p); got != tc.want {
+			t.Errorf("Percentile(%v, %v) = %v, want %v", tc.amounts, tc.p, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package models
+
+// ProfileCompleteness is a 0-100 score of how much of a profile is filled in,
+// with the labels of the pieces still missing.
+type ProfileCompleteness struct {
+	Score   int      `json:"score"`
+	Missing []string `json:"missing"`
+}
+
+// ProfileCompletenessThreshold is the score below which users are nudged
+const ProfileCompletenessThreshold = 80
+
+type completenessCheck struct {
+	label  string
+	weight int
+	done   bool
+}
+
+// Completeness scores the profile from its own fields plus the number of
+// projects and resumes attached to the user.
+func (u *User) Completeness(projectCount, resumeCount int64) ProfileCompleteness {
+	checks := []completenessCheck{
+		{"Profile photo", 10, u.AvatarURL != ""},
+		{"Bio", 10, len(u.Bio) >= 30},
+		{"At least 3 skills", 15, len(u.Skills) >= 3},
+		{"Graduation year", 10, u.GraduationYear != 0},
+		{"Location", 5, u.Location != ""},
+		{"LinkedIn profile", 10, u.LinkedInURL != ""},
+		{"GitHub profile", 5, u.GitHubURL != ""},
+		{"Resume", 10, resumeCount > 0},
+	}
+
+	switch u.Role {
+	case RoleStudent:
+		checks = append(checks,
+			completenessCheck{"Student ID", 5, u.StudentID != ""},
+			completenessCheck{"CGPA", 5, u.CGPA != 0},
+			completenessCheck{"At least one project", 15, projectCount > 0},
+		)
+	case RoleAlumni:
+		checks = append(checks,
+			completenessCheck{"Current company", 10, u.Company != ""},
+			completenessCheck{"Position", 5, u.Position != ""},
+			completenessCheck{"Experience", 10, u.Experience != ""},
+		)
+	}
+
+	total, earned := 0, 0
+	missing := []string{}
+	for _, check := range checks {
+		total += check.weight
+		if check.done {
+			earned += check.weight
+		} else {
+			missing = append(missing, check.label)
+		}
+	}
+
+	return ProfileCompleteness{
+		Score:   earned * 100 / total,
+		Missing: missing,
+	}
+}
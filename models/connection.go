@@ -0,0 +1,58 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type ConnectionStatus string
+
+const (
+	ConnectionPending  ConnectionStatus = "pending"
+	ConnectionAccepted ConnectionStatus = "accepted"
+	ConnectionDeclined ConnectionStatus = "declined"
+)
+
+type Connection struct {
+	ID          primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	RequesterID primitive.ObjectID `json:"requester_id" bson:"requester_id"`
+	AddresseeID primitive.ObjectID `json:"addressee_id" bson:"addressee_id"`
+	Status      ConnectionStatus   `json:"status" bson:"status"`
+	Message     string             `json:"message,omitempty" bson:"message,omitempty"`
+	User        *UserResponse      `json:"user,omitempty" bson:"-"` // the other participant
+	RespondedAt *time.Time         `json:"responded_at,omitempty" bson:"responded_at,omitempty"`
+	CreatedAt   time.Time          `json:"created_at" bson:"created_at"`
+	UpdatedAt   time.Time          `json:"updated_at" bson:"updated_at"`
+}
+
+type ConnectionRequest struct {
+	Message string `json:"message,omitempty" validate:"omitempty,max=300"`
+}
+
+type Follow struct {
+	ID         primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	FollowerID primitive.ObjectID `json:"follower_id" bson:"follower_id"`
+	FolloweeID primitive.ObjectID `json:"followee_id" bson:"followee_id"`
+	User       *UserResponse      `json:"user,omitempty" bson:"-"`
+	CreatedAt  time.Time          `json:"created_at" bson:"created_at"`
+}
+
+type RelationshipStatus string
+
+const (
+	RelationshipSelf            RelationshipStatus = "self"
+	RelationshipNone            RelationshipStatus = "none"
+	RelationshipPendingSent     RelationshipStatus = "pending_sent"
+	RelationshipPendingReceived RelationshipStatus = "pending_received"
+	RelationshipConnected       RelationshipStatus = "connected"
+)
+
+// Relationship describes how the current user relates to another user
+type Relationship struct {
+	Status       RelationshipStatus  `json:"status"`
+	ConnectionID *primitive.ObjectID `json:"connection_id,omitempty"`
+	IsFollowing  bool                `json:"is_following"`
+	IsFollowedBy bool                `json:"is_followed_by"`
+	MutualCount  int                 `json:"mutual_connections_count"`
+}
@@ -20,6 +20,9 @@ const (
 	EmailTypeAccountVerified  EmailNotificationType = "account_verified"
 	EmailTypeWeeklyDigest     EmailNotificationType = "weekly_digest"
 	EmailTypeMonthlyNewsletter EmailNotificationType = "monthly_newsletter"
+	EmailTypeProfileNudge     EmailNotificationType = "profile_nudge"
+	EmailTypeJobExpiry        EmailNotificationType = "job_expiry"
+	EmailTypeJobAlert         EmailNotificationType = "job_alert"
 )
 
 type EmailTemplate struct {
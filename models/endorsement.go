@@ -0,0 +1,28 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type SkillEndorsement struct {
+	ID         primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	UserID     primitive.ObjectID `json:"user_id" bson:"user_id"`
+	EndorserID primitive.ObjectID `json:"endorser_id" bson:"endorser_id"`
+	Endorser   *UserResponse      `json:"endorser,omitempty" bson:"-"`
+	Skill      string             `json:"skill" bson:"skill"`
+	SkillKey   string             `json:"-" bson:"skill_key"`
+	CreatedAt  time.Time          `json:"created_at" bson:"created_at"`
+}
+
+type EndorseSkillRequest struct {
+	Skill string `json:"skill" validate:"required,min=1,max=100"`
+}
+
+// SkillEndorsementSummary groups the endorsements a user received for one skill
+type SkillEndorsementSummary struct {
+	Skill     string          `json:"skill"`
+	Count     int             `json:"count"`
+	Endorsers []*UserResponse `json:"endorsers"`
+}
@@ -0,0 +1,57 @@
+package models
+
+// JSONResume follows the JSON Resume schema (https://jsonresume.org/schema)
+type JSONResume struct {
+	Basics    JSONResumeBasics       `json:"basics"`
+	Work      []JSONResumeWork       `json:"work,omitempty"`
+	Education []JSONResumeEducation  `json:"education,omitempty"`
+	Skills    []JSONResumeSkill      `json:"skills,omitempty"`
+	Projects  []JSONResumeProject    `json:"projects,omitempty"`
+	Meta      map[string]interface{} `json:"meta,omitempty"`
+}
+
+type JSONResumeBasics struct {
+	Name     string              `json:"name"`
+	Label    string              `json:"label,omitempty"`
+	Image    string              `json:"image,omitempty"`
+	Email    string              `json:"email,omitempty"`
+	Summary  string              `json:"summary,omitempty"`
+	Location *JSONResumeLocation `json:"location,omitempty"`
+	Profiles []JSONResumeProfile `json:"profiles,omitempty"`
+}
+
+type JSONResumeLocation struct {
+	City string `json:"city,omitempty"`
+}
+
+type JSONResumeProfile struct {
+	Network string `json:"network"`
+	URL     string `json:"url"`
+}
+
+type JSONResumeWork struct {
+	Name     string `json:"name"`
+	Position string `json:"position,omitempty"`
+	Summary  string `json:"summary,omitempty"`
+}
+
+type JSONResumeEducation struct {
+	Institution string `json:"institution"`
+	Area        string `json:"area,omitempty"`
+	StudyType   string `json:"studyType,omitempty"`
+	EndDate     string `json:"endDate,omitempty"`
+	Score       string `json:"score,omitempty"`
+}
+
+type JSONResumeSkill struct {
+	Name string `json:"name"`
+}
+
+type JSONResumeProject struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	URL         string   `json:"url,omitempty"`
+	Keywords    []string `json:"keywords,omitempty"`
+	StartDate   string   `json:"startDate,omitempty"`
+	Type        string   `json:"type,omitempty"`
+}
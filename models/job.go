@@ -16,48 +16,67 @@ const (
 )
 
 type Job struct {
-	ID                 primitive.ObjectID `json:"id" bson:"_id,omitempty"`
-	Title              string             `json:"title" bson:"title" validate:"required,min=5,max=200"`
-	Company            string             `json:"company" bson:"company" validate:"required,min=2,max=100"`
-	Location           string             `json:"location" bson:"location" validate:"required,min=2,max=100"`
-	JobType            JobType            `json:"job_type" bson:"job_type" validate:"required,oneof=full-time part-time internship contract"`
-	ExperienceRequired string             `json:"experience_required,omitempty" bson:"experience_required,omitempty"`
-	SalaryRange        string             `json:"salary_range,omitempty" bson:"salary_range,omitempty"`
-	Description        string             `json:"description" bson:"description" validate:"required,min=50,max=3000"`
-	Requirements       []string           `json:"requirements" bson:"requirements" validate:"required,min=1"`
-	PostedBy           primitive.ObjectID `json:"posted_by" bson:"posted_by"`
-	PostedByUser       *UserResponse      `json:"posted_by_user,omitempty" bson:"-"`
-	ApplicantsCount    int                `json:"applicants_count" bson:"applicants_count"`
-	IsActive           bool               `json:"is_active" bson:"is_active"`
-	ExpiresAt          *time.Time         `json:"expires_at,omitempty" bson:"expires_at,omitempty"`
-	CreatedAt          time.Time          `json:"created_at" bson:"created_at"`
-	UpdatedAt          time.Time          `json:"updated_at" bson:"updated_at"`
+	ID                 primitive.ObjectID  `json:"id" bson:"_id,omitempty"`
+	Title              string              `json:"title" bson:"title" validate:"required,min=5,max=200"`
+	Company            string              `json:"company" bson:"company" validate:"required,min=2,max=100"`
+	Location           string              `json:"location" bson:"location" validate:"required,min=2,max=100"`
+	JobType            JobType             `json:"job_type" bson:"job_type" validate:"required,oneof=full-time part-time internship contract"`
+	ExperienceRequired string              `json:"experience_required,omitempty" bson:"experience_required,omitempty"`
+	SalaryRange        string              `json:"salary_range,omitempty" bson:"salary_range,omitempty"`
+	Compensation       *Compensation       `json:"compensation,omitempty" bson:"compensation,omitempty"`
+	Description        string              `json:"description" bson:"description" validate:"required,min=50,max=3000"`
+	Requirements       []string            `json:"requirements" bson:"requirements" validate:"required,min=1"`
+	ScreeningQuestions []ScreeningQuestion `json:"screening_questions,omitempty" bson:"screening_questions,omitempty"`
+	PostedBy           primitive.ObjectID  `json:"posted_by" bson:"posted_by"`
+	PostedByUser       *UserResponse       `json:"posted_by_user,omitempty" bson:"-"`
+	ApplicantsCount    int                 `json:"applicants_count" bson:"applicants_count"`
+	Match              *SkillMatch         `json:"match,omitempty" bson:"-"`
+	IsActive           bool                `json:"is_active" bson:"is_active"`
+	ExpiresAt          *time.Time          `json:"expires_at,omitempty" bson:"expires_at,omitempty"`
+	ExpiredAt          *time.Time          `json:"expired_at,omitempty" bson:"expired_at,omitempty"`
+	ExpiryRemindedAt   *time.Time          `json:"-" bson:"expiry_reminded_at,omitempty"`
+	ExtendTokenHash    string              `json:"-" bson:"extend_token_hash,omitempty"`
+	CreatedAt          time.Time           `json:"created_at" bson:"created_at"`
+	UpdatedAt          time.Time           `json:"updated_at" bson:"updated_at"`
 }
 
 type CreateJobRequest struct {
-	Title              string    `json:"title" validate:"required,min=5,max=200"`
-	Company            string    `json:"company" validate:"required,min=2,max=100"`
-	Location           string    `json:"location" validate:"required,min=2,max=100"`
-	JobType            JobType   `json:"job_type" validate:"required,oneof=full-time part-time internship contract"`
-	ExperienceRequired string    `json:"experience_required,omitempty"`
-	SalaryRange        string    `json:"salary_range,omitempty"`
-	Description        string    `json:"description" validate:"required,min=50,max=3000"`
-	Requirements       []string  `json:"requirements" validate:"required,min=1"`
-	ExpiresAt          time.Time `json:"expires_at,omitempty"`
+	Title              string                     `json:"title" validate:"required,min=5,max=200"`
+	Company            string                     `json:"company" validate:"required,min=2,max=100"`
+	Location           string                     `json:"location" validate:"required,min=2,max=100"`
+	JobType            JobType                    `json:"job_type" validate:"required,oneof=full-time part-time internship contract"`
+	ExperienceRequired string                     `json:"experience_required,omitempty"`
+	SalaryRange        string                     `json:"salary_range,omitempty"`
+	Compensation       *Compensation              `json:"compensation,omitempty"`
+	Description        string                     `json:"description" validate:"required,min=50,max=3000"`
+	Requirements       []string                   `json:"requirements" validate:"required,min=1"`
+	ScreeningQuestions []ScreeningQuestionRequest `json:"screening_questions,omitempty" validate:"omitempty,max=10,dive"`
+	ExpiresAt          time.Time                  `json:"expires_at,omitempty"`
 }
 
 type UpdateJobRequest struct {
-	Title              string    `json:"title,omitempty" validate:"omitempty,min=5,max=200"`
-	Company            string    `json:"company,omitempty" validate:"omitempty,min=2,max=100"`
-	Location           string    `json:"location,omitempty" validate:"omitempty,min=2,max=100"`
-	JobType            JobType   `json:"job_type,omitempty" validate:"omitempty,oneof=full-time part-time internship contract"`
-	ExperienceRequired string    `json:"experience_required,omitempty"`
-	SalaryRange        string    `json:"salary_range,omitempty"`
-	Description        string    `json:"description,omitempty" validate:"omitempty,min=50,max=3000"`
-	Requirements       []string  `json:"requirements,omitempty" validate:"omitempty,min=1"`
-	ExpiresAt          time.Time `json:"expires_at,omitempty"`
+	Title              string        `json:"title,omitempty" validate:"omitempty,min=5,max=200"`
+	Company            string        `json:"company,omitempty" validate:"omitempty,min=2,max=100"`
+	Location           string        `json:"location,omitempty" validate:"omitempty,min=2,max=100"`
+	JobType            JobType       `json:"job_type,omitempty" validate:"omitempty,oneof=full-time part-time internship contract"`
+	ExperienceRequired string        `json:"experience_required,omitempty"`
+	SalaryRange        string        `json:"salary_range,omitempty"`
+	Compensation       *Compensation `json:"compensation,omitempty"`
+	Description        string        `json:"description,omitempty" validate:"omitempty,min=50,max=3000"`
+	Requirements       []string      `json:"requirements,omitempty" validate:"omitempty,min=1"`
+	// ScreeningQuestions replaces the job's questions when set; existing
+	// answers keep their own copy of the question text
+	ScreeningQuestions []ScreeningQuestionRequest `json:"screening_questions,omitempty" validate:"omitempty,max=10,dive"`
+	ExpiresAt          time.Time                  `json:"expires_at,omitempty"`
 }
 
+// ExtendJobRequest carries the token from a job expiry reminder email
+type ExtendJobRequest struct {
+	Token string `json:"token" validate:"required"`
+}
+
+// JobInterest is the one-click interest that job applications replaced. It is
+// only read when migrating old job_interests records.
 type JobInterest struct {
 	ID        primitive.ObjectID `json:"id" bson:"_id,omitempty"`
 	JobID     primitive.ObjectID `json:"job_id" bson:"job_id"`
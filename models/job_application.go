@@ -0,0 +1,103 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ApplicationStatus is a stage in the hiring pipeline. Posters move
+// candidates forward; applicants can withdraw until the pipeline ends.
+type ApplicationStatus string
+
+const (
+	ApplicationApplied     ApplicationStatus = "applied"
+	ApplicationShortlisted ApplicationStatus = "shortlisted"
+	ApplicationInterview   ApplicationStatus = "interview"
+	ApplicationOffered     ApplicationStatus = "offered"
+	ApplicationRejected    ApplicationStatus = "rejected"
+	ApplicationWithdrawn   ApplicationStatus = "withdrawn"
+)
+
+// applicationTransitions lists the stages a poster can move a candidate to
+var applicationTransitions = map[ApplicationStatus][]ApplicationStatus{
+	ApplicationApplied:     {ApplicationShortlisted, ApplicationRejected},
+	ApplicationShortlisted: {ApplicationInterview, ApplicationRejected},
+	ApplicationInterview:   {ApplicationOffered, ApplicationRejected},
+}
+
+// CanMoveTo reports whether a poster may move an application from s to next
+func (s ApplicationStatus) CanMoveTo(next ApplicationStatus) bool {
+	for _, allowed := range applicationTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// IsClosed reports whether the application has left the pipeline
+func (s ApplicationStatus) IsClosed() bool {
+	return s == ApplicationRejected || s == ApplicationWithdrawn
+}
+
+// ScreeningQuestion is a custom question a poster asks every applicant
+type ScreeningQuestion struct {
+	ID       primitive.ObjectID `json:"id" bson:"_id"`
+	Question string             `json:"question" bson:"question"`
+	Required bool               `json:"required" bson:"required"`
+}
+
+// ScreeningAnswer keeps the question text so answers stay readable after the
+// job's questions are edited
+type ScreeningAnswer struct {
+	QuestionID primitive.ObjectID `json:"question_id" bson:"question_id"`
+	Question   string             `json:"question" bson:"question"`
+	Answer     string             `json:"answer" bson:"answer"`
+}
+
+type ApplicationStatusChange struct {
+	Status    ApplicationStatus  `json:"status" bson:"status"`
+	ChangedBy primitive.ObjectID `json:"changed_by" bson:"changed_by"`
+	Note      string             `json:"note,omitempty" bson:"note,omitempty"`
+	ChangedAt time.Time          `json:"changed_at" bson:"changed_at"`
+}
+
+// JobApplication replaces the old one-click JobInterest
+type JobApplication struct {
+	ID        primitive.ObjectID        `json:"id" bson:"_id,omitempty"`
+	JobID     primitive.ObjectID        `json:"job_id" bson:"job_id"`
+	Job       *Job                      `json:"job,omitempty" bson:"-"`
+	UserID    primitive.ObjectID        `json:"user_id" bson:"user_id"`
+	User      *UserResponse             `json:"user,omitempty" bson:"-"`
+	Match     *SkillMatch               `json:"match,omitempty" bson:"-"`
+	CoverNote string                    `json:"cover_note,omitempty" bson:"cover_note,omitempty"`
+	ResumeID  *primitive.ObjectID       `json:"resume_id,omitempty" bson:"resume_id,omitempty"`
+	Resume    *Resume                   `json:"resume,omitempty" bson:"-"`
+	Answers   []ScreeningAnswer         `json:"answers" bson:"answers"`
+	Status    ApplicationStatus         `json:"status" bson:"status"`
+	History   []ApplicationStatusChange `json:"history" bson:"history"`
+	CreatedAt time.Time                 `json:"created_at" bson:"created_at"`
+	UpdatedAt time.Time                 `json:"updated_at" bson:"updated_at"`
+}
+
+type ScreeningQuestionRequest struct {
+	Question string `json:"question" validate:"required,min=3,max=500"`
+	Required bool   `json:"required"`
+}
+
+type ScreeningAnswerRequest struct {
+	QuestionID string `json:"question_id" validate:"required"`
+	Answer     string `json:"answer" validate:"max=2000"`
+}
+
+type ApplyJobRequest struct {
+	CoverNote string                   `json:"cover_note,omitempty" validate:"omitempty,max=3000"`
+	ResumeID  string                   `json:"resume_id,omitempty"`
+	Answers   []ScreeningAnswerRequest `json:"answers,omitempty" validate:"omitempty,dive"`
+}
+
+type UpdateApplicationStatusRequest struct {
+	Status ApplicationStatus `json:"status" validate:"required,oneof=shortlisted interview offered rejected"`
+	Note   string            `json:"note,omitempty" validate:"omitempty,max=500"`
+}
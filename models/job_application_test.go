@@ -0,0 +1,30 @@
+package models
+
+import "testing"
+
+func TestApplicationStatusCanMoveTo(t *testing.T) {
+	cases := []struct {
+		from ApplicationStatus
+		to   ApplicationStatus
+		want bool
+	}{
+		{ApplicationApplied, ApplicationShortlisted, true},
+		{ApplicationApplied, ApplicationRejected, true},
+		{ApplicationApplied, ApplicationInterview, false},
+		{ApplicationApplied, ApplicationOffered, false},
+		{ApplicationShortlisted, ApplicationInterview, true},
+		{ApplicationShortlisted, ApplicationApplied, false},
+		{ApplicationInterview, ApplicationOffered, true},
+		{ApplicationInterview, ApplicationRejected, true},
+		{ApplicationOffered, ApplicationRejected, false},
+		{ApplicationRejected, ApplicationShortlisted, false},
+		{ApplicationWithdrawn, ApplicationShortlisted, false},
+		{ApplicationApplied, ApplicationWithdrawn, false},
+	}
+
+	for _, tc := range cases {
+		if got := tc.from.CanMoveTo(tc.to); got != tc.want {
+			t.Errorf("%s.CanMoveTo(%s) = %v, want %v", tc.from, tc.to, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package models
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Skill match weights. Covering the job's requirements counts most; skills
+// the description mentions make up the rest, up to descriptionMatchCap.
+const (
+	requirementMatchWeight = 80.0
+	descriptionMatchWeight = 20.0
+	descriptionMatchCap    = 3
+)
+
+// SkillMatch scores how well a candidate's skills fit a job, from 0 to 100
+type SkillMatch struct {
+	Score int `json:"score"`
+	// MatchedSkills are the candidate's skills found in the job
+	MatchedSkills []string `json:"matched_skills"`
+	// MissingSkills are requirements none of the candidate's skills cover
+	MissingSkills []string `json:"missing_skills"`
+}
+
+// ScoreSkillMatch compares a candidate's skills, keyed by lowercase name,
+// with a job's requirements and description
+func ScoreSkillMatch(skills map[string]string, job *Job) *SkillMatch {
+	match := &SkillMatch{
+		MatchedSkills: []string{},
+		MissingSkills: []string{},
+	}
+
+	matched := map[string]bool{}
+	requirements, covered := 0, 0
+	for _, requirement := range job.Requirements {
+		text := strings.ToLower(strings.TrimSpace(requirement))
+		if text == "" {
+			continue
+		}
+		requirements++
+
+		found := false
+		for key := range skills {
+			if containsTerm(text, key) {
+				matched[key] = true
+				found = true
+			}
+		}
+		if found {
+			covered++
+		} else {
+			match.MissingSkills = append(match.MissingSkills, strings.TrimSpace(requirement))
+		}
+	}
+
+	description := strings.ToLower(job.Description)
+	mentioned := 0
+	for key := range skills {
+		if containsTerm(description, key) {
+			matched[key] = true
+			mentioned++
+		}
+	}
+
+	score := 0.0
+	if requirements > 0 {
+		score += requirementMatchWeight * float64(covered) / float64(requirements)
+	}
+	score += descriptionMatchWeight * float64(min(mentioned, descriptionMatchCap)) / descriptionMatchCap
+	match.Score = int(math.Round(score))
+
+	for key := range matched {
+		match.MatchedSkills = append(match.MatchedSkills, skills[key])
+	}
+	sort.Strings(match.MatchedSkills)
+
+	return match
+}
+
+// containsTerm reports whether term occurs in text as a whole word, so "go"
+// matches "Go developer" but not "good", and "c" does not match "c++" or
+// "c#". Terms may contain symbols such as "c++" or "node.js".
+func containsTerm(text, term string) bool {
+	if term == "" {
+		return false
+	}
+	for start := 0; start < len(text); {
+		i := strings.Index(text[start:], term)
+		if i < 0 {
+			return false
+		}
+		i += start
+		end := i + len(term)
+		if !wordRuneBefore(text, i) && !wordRuneAfter(text, end) {
+			return true
+		}
+		start = i + 1
+	}
+	return false
+}
+
+func wordRuneBefore(text string, i int) bool {
+	if i == 0 {
+		return false
+	}
+	r, _ := utf8.DecodeLastRuneInString(text[:i])
+	return isWordRune(r)
+}
+
+func wordRuneAfter(text string, i int) bool {
+	if i == len(text) {
+		return false
+	}
+	r, _ := utf8.DecodeRuneInString(text[i:])
+	return isWordRune(r)
+}
+
+// isWordRune reports whether r continues a word. "+" and "#" count so that
+// language names like "c++" and "c#" are not split.
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '+' || r == '#'
+}
@@ -0,0 +1,114 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestContainsTerm(t *testing.T) {
+	cases := []struct {
+		text string
+		term string
+		want bool
+	}{
+		{"go developer", "go", true},
+		{"good communication", "go", false},
+		{"experience with go, rust", "go", true},
+		{"golang or go.", "go", true},
+		{"c++ and python", "c", false},
+		{"c# and .net", "c", false},
+		{"c and embedded systems", "c", true},
+		{"c++ and python", "c++", true},
+		{"node.js backend", "node.js", true},
+		{"requirements gathering", "r", false},
+		{"statistics in r", "r", true},
+		{"anything", "", false},
+	}
+
+	for _, tc := range cases {
+		if got := containsTerm(tc.text, tc.term); got != tc.want {
+			t.Errorf("containsTerm(%q, %q) = %v, want %v", tc.text, tc.term, got, tc.want)
+		}
+	}
+}
+
+func TestScoreSkillMatch(t *testing.T) {
+	cases := []struct {
+		name         string
+		skills       map[string]string
+		requirements []string
+		description  string
+		score        int
+		matched      []string
+		missing      []string
+	}{
+		{
+			name:         "all requirements covered",
+			skills:       map[string]string{"go": "Go", "mongodb": "MongoDB"},
+			requirements: []string{"Go", "MongoDB"},
+			description:  "Backend role",
+			score:        80,
+			matched:      []string{"Go", "MongoDB"},
+			missing:      []string{},
+		},
+		{
+			name:         "partial word is not a match",
+			skills:       map[string]string{"go": "Go"},
+			requirements: []string{"Good communication"},
+			description:  "A good team",
+			score:        0,
+			matched:      []string{},
+			missing:      []string{"Good communication"},
+		},
+		{
+			name:         "c does not cover c++",
+			skills:       map[string]string{"c": "C"},
+			requirements: []string{"C++", "C"},
+			description:  "Systems work",
+			score:        40,
+			matched:      []string{"C"},
+			missing:      []string{"C++"},
+		},
+		{
+			name:         "no overlap",
+			skills:       map[string]string{"python": "Python"},
+			requirements: []string{"Java", "Spring"},
+			description:  "Enterprise services",
+			score:        0,
+			matched:      []string{},
+			missing:      []string{"Java", "Spring"},
+		},
+		{
+			name:         "empty requirements score the description only",
+			skills:       map[string]string{"react": "React", "go": "Go", "docker": "Docker", "aws": "AWS"},
+			requirements: []string{"", "  "},
+			description:  "We use React, Go, Docker and AWS",
+			score:        20,
+			matched:      []string{"AWS", "Docker", "Go", "React"},
+			missing:      []string{},
+		},
+		{
+			name:         "no skills",
+			skills:       map[string]string{},
+			requirements: []string{"Go"},
+			description:  "Go services",
+			score:        0,
+			matched:      []string{},
+			missing:      []string{"Go"},
+		},
+	}
+
+	for _, tc := range cases {
+		job := &Job{Requirements: tc.requirements, Description: tc.description}
+		match := ScoreSkillMatch(tc.skills, job)
+		if match.Score != tc.score {
+			t.Errorf("%s: score = %d, want %d", tc.name, match.Score, tc.score)
+		}
+		if !reflect.DeepEqual(match.MatchedSkills, tc.matched) {
+			t.Errorf("%s: matched = %v, want %v", tc.name, match.MatchedSkills, tc.matched)
+		}
+		if !reflect.DeepEqual(match.MissingSkills, tc.missing) {
+			t.Errorf("%s: missing = %v, want %v", tc.name, match.MissingSkills, tc.missing)
+		}
+	}
+}
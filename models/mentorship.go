@@ -0,0 +1,70 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type MentorProfile struct {
+	ID            primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	UserID        primitive.ObjectID `json:"user_id" bson:"user_id"`
+	User          *UserResponse      `json:"user,omitempty" bson:"-"`
+	Topics        []string           `json:"topics" bson:"topics"`
+	Capacity      int                `json:"capacity" bson:"capacity"`
+	ActiveMentees int                `json:"active_mentees" bson:"active_mentees"`
+	About         string             `json:"about,omitempty" bson:"about,omitempty"`
+	IsAccepting   bool               `json:"is_accepting" bson:"is_accepting"`
+	CreatedAt     time.Time          `json:"created_at" bson:"created_at"`
+	UpdatedAt     time.Time          `json:"updated_at" bson:"updated_at"`
+}
+
+type MentorProfileRequest struct {
+	Topics      []string `json:"topics" validate:"required,min=1,max=20"`
+	Capacity    int      `json:"capacity" validate:"required,min=1,max=20"`
+	About       string   `json:"about,omitempty" validate:"omitempty,max=1000"`
+	IsAccepting *bool    `json:"is_accepting,omitempty"`
+}
+
+type MentorshipStatus string
+
+const (
+	MentorshipPending   MentorshipStatus = "pending"
+	MentorshipActive    MentorshipStatus = "active"
+	MentorshipDeclined  MentorshipStatus = "declined"
+	MentorshipCompleted MentorshipStatus = "completed"
+	MentorshipCancelled MentorshipStatus = "cancelled"
+)
+
+type Mentorship struct {
+	ID           primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	MentorID     primitive.ObjectID `json:"mentor_id" bson:"mentor_id"`
+	MenteeID     primitive.ObjectID `json:"mentee_id" bson:"mentee_id"`
+	Mentor       *UserResponse      `json:"mentor,omitempty" bson:"-"`
+	Mentee       *UserResponse      `json:"mentee,omitempty" bson:"-"`
+	Topic        string             `json:"topic,omitempty" bson:"topic,omitempty"`
+	Message      string             `json:"message" bson:"message"`
+	Status       MentorshipStatus   `json:"status" bson:"status"`
+	ResponseNote string             `json:"response_note,omitempty" bson:"response_note,omitempty"`
+	AcceptedAt   *time.Time         `json:"accepted_at,omitempty" bson:"accepted_at,omitempty"`
+	CompletedAt  *time.Time         `json:"completed_at,omitempty" bson:"completed_at,omitempty"`
+	CreatedAt    time.Time          `json:"created_at" bson:"created_at"`
+	UpdatedAt    time.Time          `json:"updated_at" bson:"updated_at"`
+}
+
+type MentorshipRequest struct {
+	MentorID string `json:"mentor_id" validate:"required"`
+	Topic    string `json:"topic,omitempty" validate:"omitempty,max=100"`
+	Message  string `json:"message" validate:"required,min=10,max=1000"`
+}
+
+type MentorshipResponseRequest struct {
+	Note string `json:"note,omitempty" validate:"omitempty,max=500"`
+}
+
+// MentorMatch is a mentor ranked against a student's skills
+type MentorMatch struct {
+	Mentor        *MentorProfile `json:"mentor"`
+	Score         int            `json:"score"`
+	MatchedSkills []string       `json:"matched_skills"`
+}
@@ -36,6 +36,12 @@ type UserPreferences struct {
 	ID                 primitive.ObjectID `bson:"_id,omitempty"`
 	UserID             primitive.ObjectID `bson:"user_id"`
 	EmailNotifications struct {
-		Messages bool `bson:"messages"`
+		Messages      bool `json:"messages" bson:"messages"`
+		ProfileNudges bool `json:"profile_nudges" bson:"profile_nudges"`
 	} `bson:"email_notifications"`
 }
+
+type UpdateEmailPreferencesRequest struct {
+	Messages      *bool `json:"messages,omitempty"`
+	ProfileNudges *bool `json:"profile_nudges,omitempty"`
+}
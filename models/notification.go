@@ -14,6 +14,21 @@ const (
 	NotificationEventCreated     NotificationType = "event_created"
 	NotificationProjectLiked     NotificationType = "project_liked"
 	NotificationInterestReceived NotificationType = "interest_received"
+	NotificationSkillEndorsed    NotificationType = "skill_endorsed"
+	NotificationConnectionReq    NotificationType = "connection_request"
+	NotificationConnectionAccept NotificationType = "connection_accepted"
+	NotificationNewFollower      NotificationType = "new_follower"
+	NotificationMentorshipReq    NotificationType = "mentorship_request"
+	NotificationMentorshipUpdate NotificationType = "mentorship_update"
+	NotificationCohortUpdate     NotificationType = "cohort_announcement"
+	NotificationProfileNudge     NotificationType = "profile_nudge"
+	NotificationProjectInvite    NotificationType = "project_invitation"
+	NotificationProjectTeam      NotificationType = "project_team_update"
+	NotificationProjectComment   NotificationType = "project_comment"
+	NotificationMentioned        NotificationType = "mentioned"
+	NotificationProjectReview    NotificationType = "project_review"
+	NotificationJobApplication   NotificationType = "application_update"
+	NotificationReferral         NotificationType = "referral_update"
 )
 
 type Notification struct {
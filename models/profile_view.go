@@ -0,0 +1,31 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ProfileView records that a viewer looked at a profile on a given day.
+// Repeat views on the same day update LastViewedAt instead of adding entries.
+type ProfileView struct {
+	ID            primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	ViewerID      primitive.ObjectID `json:"-" bson:"viewer_id"`
+	ViewedID      primitive.ObjectID `json:"viewed_id" bson:"viewed_id"`
+	Day           string             `json:"day" bson:"day"` // YYYY-MM-DD
+	FirstViewedAt time.Time          `json:"first_viewed_at" bson:"first_viewed_at"`
+	LastViewedAt  time.Time          `json:"last_viewed_at" bson:"last_viewed_at"`
+}
+
+// ProfileViewer is an entry in the owner's recent viewers list. Viewers who
+// hide their profile views or whose profile is not visible are anonymous.
+type ProfileViewer struct {
+	Viewer    *UserResponse `json:"viewer,omitempty"`
+	Anonymous bool          `json:"anonymous"`
+	ViewedAt  time.Time     `json:"viewed_at"`
+}
+
+type ProfileViewDay struct {
+	Day   string `json:"day" bson:"_id"`
+	Views int    `json:"views" bson:"views"`
+}
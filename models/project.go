@@ -13,21 +13,86 @@ const (
 	ProjectTypeMajor ProjectType = "major"
 )
 
+// ProjectStatus tracks a project through faculty review. Projects created
+// before the review workflow have no status and are treated as approved.
+type ProjectStatus string
+
+const (
+	ProjectDraft            ProjectStatus = "draft"
+	ProjectSubmitted        ProjectStatus = "submitted"
+	ProjectUnderReview      ProjectStatus = "under_review"
+	ProjectApproved         ProjectStatus = "approved"
+	ProjectChangesRequested ProjectStatus = "changes_requested"
+)
+
 type Project struct {
-	ID           primitive.ObjectID `json:"id" bson:"_id,omitempty"`
-	Title        string             `json:"title" bson:"title" validate:"required,min=5,max=200"`
-	Description  string             `json:"description" bson:"description" validate:"required,min=20,max=2000"`
-	ProjectType  ProjectType        `json:"project_type" bson:"project_type" validate:"required,oneof=mini major"`
-	Technologies []string           `json:"technologies" bson:"technologies" validate:"required,min=1"`
-	GitHubURL    string             `json:"github_url,omitempty" bson:"github_url,omitempty" validate:"omitempty,url"`
-	DemoURL      string             `json:"demo_url,omitempty" bson:"demo_url,omitempty" validate:"omitempty,url"`
-	AuthorID     primitive.ObjectID `json:"author_id" bson:"author_id"`
-	Author       *UserResponse      `json:"author,omitempty" bson:"-"`
-	LikesCount   int                `json:"likes_count" bson:"likes_count"`
-	ViewsCount   int                `json:"views_count" bson:"views_count"`
-	IsActive     bool               `json:"is_active" bson:"is_active"`
-	CreatedAt    time.Time          `json:"created_at" bson:"created_at"`
-	UpdatedAt    time.Time          `json:"updated_at" bson:"updated_at"`
+	ID               primitive.ObjectID   `json:"id" bson:"_id,omitempty"`
+	Title            string               `json:"title" bson:"title" validate:"required,min=5,max=200"`
+	Description      string               `json:"description" bson:"description" validate:"required,min=20,max=2000"`
+	ProjectType      ProjectType          `json:"project_type" bson:"project_type" validate:"required,oneof=mini major"`
+	Technologies     []string             `json:"technologies" bson:"technologies" validate:"required,min=1"`
+	GitHubURL        string               `json:"github_url,omitempty" bson:"github_url,omitempty" validate:"omitempty,url"`
+	DemoURL          string               `json:"demo_url,omitempty" bson:"demo_url,omitempty" validate:"omitempty,url"`
+	RepoMetadata     *RepoMetadata        `json:"repo_metadata,omitempty" bson:"repo_metadata,omitempty"`
+	SyncTechnologies bool                 `json:"sync_technologies" bson:"sync_technologies"`
+	Version          int                  `json:"version" bson:"version"`
+	AuthorID         primitive.ObjectID   `json:"author_id" bson:"author_id"`
+	Author           *UserResponse        `json:"author,omitempty" bson:"-"`
+	Contributors     []ProjectContributor `json:"contributors,omitempty" bson:"contributors,omitempty"`
+	LikesCount       int                  `json:"likes_count" bson:"likes_count"`
+	ViewsCount       int                  `json:"views_count" bson:"views_count"`
+	CommentsCount    int                  `json:"comments_count" bson:"comments_count"`
+	Ranking          *ProjectRanking      `json:"ranking,omitempty" bson:"ranking,omitempty"`
+	Status           ProjectStatus        `json:"status" bson:"status,omitempty"`
+	ReviewerID       *primitive.ObjectID  `json:"reviewer_id,omitempty" bson:"reviewer_id,omitempty"`
+	Reviewer         *UserResponse        `json:"reviewer,omitempty" bson:"-"`
+	SubmittedAt      *time.Time           `json:"submitted_at,omitempty" bson:"submitted_at,omitempty"`
+	ApprovedAt       *time.Time           `json:"approved_at,omitempty" bson:"approved_at,omitempty"`
+	IsFeatured       bool                 `json:"is_featured" bson:"is_featured"`
+	CoverImageURL    string               `json:"cover_image_url,omitempty" bson:"cover_image_url,omitempty"`
+	Media            []ProjectMedia       `json:"media,omitempty" bson:"-"`
+	IsActive         bool                 `json:"is_active" bson:"is_active"`
+	CreatedAt        time.Time            `json:"created_at" bson:"created_at"`
+	UpdatedAt        time.Time            `json:"updated_at" bson:"updated_at"`
+}
+
+type ContributorRole string
+
+const (
+	ContributorDeveloper  ContributorRole = "developer"
+	ContributorDesigner   ContributorRole = "designer"
+	ContributorResearcher ContributorRole = "researcher"
+	ContributorTester     ContributorRole = "tester"
+	ContributorOther      ContributorRole = "other"
+)
+
+type ContributorStatus string
+
+const (
+	ContributorInvited  ContributorStatus = "invited"
+	ContributorAccepted ContributorStatus = "accepted"
+	ContributorDeclined ContributorStatus = "declined"
+)
+
+// ProjectContributor is a co-author of a project. The project author is the
+// owner and is not listed here; other members join by accepting an invitation.
+type ProjectContributor struct {
+	UserID      primitive.ObjectID `json:"user_id" bson:"user_id"`
+	User        *UserResponse      `json:"user,omitempty" bson:"-"`
+	Role        ContributorRole    `json:"role" bson:"role"`
+	Status      ContributorStatus  `json:"status" bson:"status"`
+	InvitedBy   primitive.ObjectID `json:"invited_by" bson:"invited_by"`
+	InvitedAt   time.Time          `json:"invited_at" bson:"invited_at"`
+	RespondedAt *time.Time         `json:"responded_at,omitempty" bson:"responded_at,omitempty"`
+}
+
+type InviteContributorRequest struct {
+	UserID string          `json:"user_id" validate:"required"`
+	Role   ContributorRole `json:"role" validate:"required,oneof=developer designer researcher tester other"`
+}
+
+type UpdateContributorRequest struct {
+	Role ContributorRole `json:"role" validate:"required,oneof=developer designer researcher tester other"`
 }
 
 type CreateProjectRequest struct {
@@ -37,15 +102,18 @@ type CreateProjectRequest struct {
 	Technologies []string    `json:"technologies" validate:"required,min=1"`
 	GitHubURL    string      `json:"github_url,omitempty" validate:"omitempty,url"`
 	DemoURL      string      `json:"demo_url,omitempty" validate:"omitempty,url"`
+	// Add the repository's main languages to Technologies
+	SyncTechnologies bool `json:"sync_technologies,omitempty"`
 }
 
 type UpdateProjectRequest struct {
-	Title        string      `json:"title,omitempty" validate:"omitempty,min=5,max=200"`
-	Description  string      `json:"description,omitempty" validate:"omitempty,min=20,max=2000"`
-	ProjectType  ProjectType `json:"project_type,omitempty" validate:"omitempty,oneof=mini major"`
-	Technologies []string    `json:"technologies,omitempty" validate:"omitempty,min=1"`
-	GitHubURL    string      `json:"github_url,omitempty" validate:"omitempty,url"`
-	DemoURL      string      `json:"demo_url,omitempty" validate:"omitempty,url"`
+	Title            string      `json:"title,omitempty" validate:"omitempty,min=5,max=200"`
+	Description      string      `json:"description,omitempty" validate:"omitempty,min=20,max=2000"`
+	ProjectType      ProjectType `json:"project_type,omitempty" validate:"omitempty,oneof=mini major"`
+	Technologies     []string    `json:"technologies,omitempty" validate:"omitempty,min=1"`
+	GitHubURL        string      `json:"github_url,omitempty" validate:"omitempty,url"`
+	DemoURL          string      `json:"demo_url,omitempty" validate:"omitempty,url"`
+	SyncTechnologies *bool       `json:"sync_technologies,omitempty"`
 }
 
 type ProjectLike struct {
@@ -54,3 +122,21 @@ type ProjectLike struct {
 	UserID    primitive.ObjectID `json:"user_id" bson:"user_id"`
 	CreatedAt time.Time          `json:"created_at" bson:"created_at"`
 }
+
+// IsApproved reports whether the project is publicly listed
+func (p *Project) IsApproved() bool {
+	return p.Status == "" || p.Status == ProjectApproved
+}
+
+// IsMember reports whether the user is the author or an accepted contributor
+func (p *Project) IsMember(userID primitive.ObjectID) bool {
+	if p.AuthorID == userID {
+		return true
+	}
+	for _, contributor := range p.Contributors {
+		if contributor.UserID == userID && contributor.Status == ContributorAccepted {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,41 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type MediaType string
+
+const (
+	MediaImage    MediaType = "image"
+	MediaDocument MediaType = "document"
+	MediaVideo    MediaType = "video"
+)
+
+// ProjectMedia is a file attached to a project. Position orders the media
+// within the project; at most one image is the project's cover.
+type ProjectMedia struct {
+	ID          primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	ProjectID   primitive.ObjectID `json:"project_id" bson:"project_id"`
+	UploadedBy  primitive.ObjectID `json:"uploaded_by" bson:"uploaded_by"`
+	MediaType   MediaType          `json:"media_type" bson:"media_type"`
+	FileName    string             `json:"file_name" bson:"file_name"`
+	StoredName  string             `json:"-" bson:"stored_name"`
+	URL         string             `json:"url" bson:"url"`
+	ContentType string             `json:"content_type" bson:"content_type"`
+	Size        int64              `json:"size" bson:"size"`
+	Caption     string             `json:"caption,omitempty" bson:"caption,omitempty"`
+	Position    int                `json:"position" bson:"position"`
+	IsCover     bool               `json:"is_cover" bson:"is_cover"`
+	CreatedAt   time.Time          `json:"created_at" bson:"created_at"`
+}
+
+type UpdateProjectMediaRequest struct {
+	Caption string `json:"caption" validate:"max=300"`
+}
+
+type ReorderProjectMediaRequest struct {
+	MediaIDs []string `json:"media_ids" validate:"required,min=1"`
+}
@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// ProjectRanking holds precomputed ranking signals for a project. It is
+// refreshed in the background and used to sort project listings.
+type ProjectRanking struct {
+	TrendingScore float64   `json:"trending_score" bson:"trending_score"`
+	LikesWeek     int       `json:"likes_week" bson:"likes_week"`
+	LikesMonth    int       `json:"likes_month" bson:"likes_month"`
+	ViewsWeek     int       `json:"views_week" bson:"views_week"`
+	ViewsMonth    int       `json:"views_month" bson:"views_month"`
+	ComputedAt    time.Time `json:"computed_at" bson:"computed_at"`
+}
@@ -0,0 +1,56 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type ReviewDecision string
+
+const (
+	ReviewApproved         ReviewDecision = "approved"
+	ReviewChangesRequested ReviewDecision = "changes_requested"
+)
+
+// RubricScores are the reviewer's marks for each rubric criterion, out of 10
+type RubricScores struct {
+	Innovation     int `json:"innovation" bson:"innovation" validate:"required,min=1,max=10"`
+	TechnicalDepth int `json:"technical_depth" bson:"technical_depth" validate:"required,min=1,max=10"`
+	Implementation int `json:"implementation" bson:"implementation" validate:"required,min=1,max=10"`
+	Documentation  int `json:"documentation" bson:"documentation" validate:"required,min=1,max=10"`
+	Presentation   int `json:"presentation" bson:"presentation" validate:"required,min=1,max=10"`
+}
+
+// Total returns the sum of all rubric scores
+func (r RubricScores) Total() int {
+	return r.Innovation + r.TechnicalDepth + r.Implementation + r.Documentation + r.Presentation
+}
+
+// RubricMaxScore is the highest total a project can be given
+const RubricMaxScore = 50
+
+type ProjectReview struct {
+	ID        primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	ProjectID primitive.ObjectID `json:"project_id" bson:"project_id"`
+	// ProjectVersion is the version of the project that was reviewed
+	ProjectVersion int                `json:"project_version" bson:"project_version"`
+	ReviewerID     primitive.ObjectID `json:"reviewer_id" bson:"reviewer_id"`
+	Reviewer       *UserResponse      `json:"reviewer,omitempty" bson:"-"`
+	Decision       ReviewDecision     `json:"decision" bson:"decision"`
+	Scores         RubricScores       `json:"scores" bson:"scores"`
+	TotalScore     int                `json:"total_score" bson:"total_score"`
+	MaxScore       int                `json:"max_score" bson:"max_score"`
+	Comments       string             `json:"comments" bson:"comments"`
+	CreatedAt      time.Time          `json:"created_at" bson:"created_at"`
+}
+
+type SubmitReviewRequest struct {
+	Decision ReviewDecision `json:"decision" validate:"required,oneof=approved changes_requested"`
+	Scores   RubricScores   `json:"scores" validate:"required"`
+	Comments string         `json:"comments" validate:"required,min=10,max=2000"`
+}
+
+type AssignReviewerRequest struct {
+	ReviewerID string `json:"reviewer_id" validate:"required"`
+}
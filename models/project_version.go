@@ -0,0 +1,103 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ProjectSnapshot is the versioned content of a project
+type ProjectSnapshot struct {
+	Title        string      `json:"title" bson:"title"`
+	Description  string      `json:"description" bson:"description"`
+	ProjectType  ProjectType `json:"project_type" bson:"project_type"`
+	Technologies []string    `json:"technologies" bson:"technologies"`
+	GitHubURL    string      `json:"github_url,omitempty" bson:"github_url,omitempty"`
+	DemoURL      string      `json:"demo_url,omitempty" bson:"demo_url,omitempty"`
+}
+
+// Snapshot returns the project's versioned content
+func (p *Project) Snapshot() ProjectSnapshot {
+	return ProjectSnapshot{
+		Title:        p.Title,
+		Description:  p.Description,
+		ProjectType:  p.ProjectType,
+		Technologies: p.Technologies,
+		GitHubURL:    p.GitHubURL,
+		DemoURL:      p.DemoURL,
+	}
+}
+
+// ProjectVersion is the state of a project after an edit
+type ProjectVersion struct {
+	ID           primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	ProjectID    primitive.ObjectID `json:"project_id" bson:"project_id"`
+	Version      int                `json:"version" bson:"version"`
+	Snapshot     ProjectSnapshot    `json:"snapshot" bson:"snapshot"`
+	EditedBy     primitive.ObjectID `json:"edited_by" bson:"edited_by"`
+	Editor       *UserResponse      `json:"editor,omitempty" bson:"-"`
+	RestoredFrom *int               `json:"restored_from,omitempty" bson:"restored_from,omitempty"`
+	CreatedAt    time.Time          `json:"created_at" bson:"created_at"`
+}
+
+// FieldChange is one changed field between two project versions. List
+// fields also report which entries were added and removed.
+type FieldChange struct {
+	Field   string      `json:"field"`
+	From    interface{} `json:"from"`
+	To      interface{} `json:"to"`
+	Added   []string    `json:"added,omitempty"`
+	Removed []string    `json:"removed,omitempty"`
+}
+
+type ProjectDiff struct {
+	FromVersion int           `json:"from_version"`
+	ToVersion   int           `json:"to_version"`
+	Changes     []FieldChange `json:"changes"`
+}
+
+// Diff lists the fields that differ from the other snapshot
+func (s ProjectSnapshot) Diff(other ProjectSnapshot) []FieldChange {
+	changes := []FieldChange{}
+	addString := func(field, from, to string) {
+		if from != to {
+			changes = append(changes, FieldChange{Field: field, From: from, To: to})
+		}
+	}
+
+	addString("title", s.Title, other.Title)
+	addString("description", s.Description, other.Description)
+	addString("project_type", string(s.ProjectType), string(other.ProjectType))
+
+	before := map[string]bool{}
+	for _, technology := range s.Technologies {
+		before[technology] = true
+	}
+	after := map[string]bool{}
+	var added, removed []string
+	for _, technology := range other.Technologies {
+		after[technology] = true
+		if !before[technology] {
+			added = append(added, technology)
+		}
+	}
+	for _, technology := range s.Technologies {
+		if !after[technology] {
+			removed = append(removed, technology)
+		}
+	}
+	if len(added) > 0 || len(removed) > 0 {
+		changes = append(changes, FieldChange{
+			Field:   "technologies",
+			From:    s.Technologies,
+			To:      other.Technologies,
+			Added:   added,
+			Removed: removed,
+		})
+	}
+
+	addString("github_url", s.GitHubURL, other.GitHubURL)
+	addString("demo_url", s.DemoURL, other.DemoURL)
+
+	return changes
+}
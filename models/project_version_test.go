@@ -0,0 +1,68 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestProjectSnapshotDiff(t *testing.T) {
+	base := ProjectSnapshot{
+		Title:        "Alumni Portal",
+		Description:  "A portal for alumni",
+		ProjectType:  ProjectTypeMajor,
+		Technologies: []string{"Go", "MongoDB"},
+		GitHubURL:    "https://github.com/octo/portal",
+	}
+
+	cases := []struct {
+		name    string
+		edit    func(*ProjectSnapshot)
+		changes []FieldChange
+	}{
+		{
+			name:    "unchanged",
+			edit:    func(s *ProjectSnapshot) {},
+			changes: []FieldChange{},
+		},
+		{
+			name: "title and demo URL",
+			edit: func(s *ProjectSnapshot) {
+				s.Title = "Alumni Hub"
+				s.DemoURL = "https://portal.example.com"
+			},
+			changes: []FieldChange{
+				{Field: "title", From: "Alumni Portal", To: "Alumni Hub"},
+				{Field: "demo_url", From: "", To: "https://portal.example.com"},
+			},
+		},
+		{
+			name: "technologies added and removed",
+			edit: func(s *ProjectSnapshot) {
+				s.Technologies = []string{"Go", "Redis"}
+			},
+			changes: []FieldChange{{
+				Field:   "technologies",
+				From:    []string{"Go", "MongoDB"},
+				To:      []string{"Go", "Redis"},
+				Added:   []string{"Redis"},
+				Removed: []string{"MongoDB"},
+			}},
+		},
+		{
+			name: "technologies reordered",
+			edit: func(s *ProjectSnapshot) {
+				s.Technologies = []string{"MongoDB", "Go"}
+			},
+			changes: []FieldChange{},
+		},
+	}
+
+	for _, tc := range cases {
+		other := base
+		other.Technologies = append([]string{}, base.Technologies...)
+		tc.edit(&other)
+		if changes := base.Diff(other); !reflect.DeepEqual(changes, tc.changes) {
+			t.Errorf("%s: Diff = %+v, want %+v", tc.name, changes, tc.changes)
+		}
+	}
+}
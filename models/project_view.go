@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ProjectView tracks when a viewer last looked at a project. A view is only
+// counted again once the dedupe window has passed.
+type ProjectView struct {
+	ID            primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	ProjectID     primitive.ObjectID `json:"project_id" bson:"project_id"`
+	ViewerID      primitive.ObjectID `json:"-" bson:"viewer_id"`
+	FirstViewedAt time.Time          `json:"first_viewed_at" bson:"first_viewed_at"`
+	LastViewedAt  time.Time          `json:"last_viewed_at" bson:"last_viewed_at"`
+	// CountedAt is when a view by this viewer was last counted
+	CountedAt time.Time `json:"counted_at" bson:"counted_at"`
+}
+
+// ProjectViewBucket holds a project's counted views for one day
+type ProjectViewBucket struct {
+	ProjectID primitive.ObjectID `json:"project_id" bson:"project_id"`
+	Day       string             `json:"day" bson:"day"` // YYYY-MM-DD
+	Views     int                `json:"views" bson:"views"`
+	Viewers   int                `json:"viewers" bson:"viewers"`
+}
+
+// ProjectAnalyticsDay is one point of a project's view/like time series
+type ProjectAnalyticsDay struct {
+	Day     string `json:"day"`
+	Views   int    `json:"views"`
+	Viewers int    `json:"viewers"`
+	Likes   int    `json:"likes"`
+}
@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// PersonRecommendation is a suggested user together with why they were suggested
+type PersonRecommendation struct {
+	UserID         primitive.ObjectID `json:"user_id" bson:"user_id"`
+	User           *UserResponse      `json:"user,omitempty" bson:"-"`
+	Score          int                `json:"score" bson:"score"`
+	Reasons        []string           `json:"reasons" bson:"reasons"`
+	SharedSkills   []string           `json:"shared_skills,omitempty" bson:"shared_skills,omitempty"`
+	SharedEvents   int                `json:"shared_events" bson:"shared_events"`
+	MutualContacts int                `json:"mutual_contacts" bson:"mutual_contacts"`
+}
+
+// RecommendationCache holds the last computed suggestions for a user
+type RecommendationCache struct {
+	ID              primitive.ObjectID     `json:"id" bson:"_id,omitempty"`
+	UserID          primitive.ObjectID     `json:"user_id" bson:"user_id"`
+	Recommendations []PersonRecommendation `json:"recommendations" bson:"recommendations"`
+	ComputedAt      time.Time              `json:"computed_at" bson:"computed_at"`
+	ExpiresAt       time.Time              `json:"expires_at" bson:"expires_at"`
+}
@@ -0,0 +1,65 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ReferralStatus is the alumnus' answer to a referral request. Students can
+// cancel requests that are still pending.
+type ReferralStatus string
+
+const (
+	ReferralPending   ReferralStatus = "pending"
+	ReferralAccepted  ReferralStatus = "accepted"
+	ReferralDeclined  ReferralStatus = "declined"
+	ReferralCancelled ReferralStatus = "cancelled"
+)
+
+// ReferralOutcome tracks what happened after an accepted referral
+type ReferralOutcome string
+
+const (
+	ReferralOutcomeReferred     ReferralOutcome = "referred"
+	ReferralOutcomeInterviewing ReferralOutcome = "interviewing"
+	ReferralOutcomeHired        ReferralOutcome = "hired"
+	ReferralOutcomeNotSelected  ReferralOutcome = "not_selected"
+)
+
+// ReferralRequest asks an alumnus who works at a job's company to refer a
+// student for it
+type ReferralRequest struct {
+	ID           primitive.ObjectID  `json:"id" bson:"_id,omitempty"`
+	JobID        primitive.ObjectID  `json:"job_id" bson:"job_id"`
+	Job          *Job                `json:"job,omitempty" bson:"-"`
+	StudentID    primitive.ObjectID  `json:"student_id" bson:"student_id"`
+	Student      *UserResponse       `json:"student,omitempty" bson:"-"`
+	AlumnusID    primitive.ObjectID  `json:"alumnus_id" bson:"alumnus_id"`
+	Alumnus      *UserResponse       `json:"alumnus,omitempty" bson:"-"`
+	Note         string              `json:"note" bson:"note"`
+	ResumeID     *primitive.ObjectID `json:"resume_id,omitempty" bson:"resume_id,omitempty"`
+	Resume       *Resume             `json:"resume,omitempty" bson:"-"`
+	Status       ReferralStatus      `json:"status" bson:"status"`
+	ResponseNote string              `json:"response_note,omitempty" bson:"response_note,omitempty"`
+	Outcome      ReferralOutcome     `json:"outcome,omitempty" bson:"outcome,omitempty"`
+	RespondedAt  *time.Time          `json:"responded_at,omitempty" bson:"responded_at,omitempty"`
+	OutcomeAt    *time.Time          `json:"outcome_at,omitempty" bson:"outcome_at,omitempty"`
+	CreatedAt    time.Time           `json:"created_at" bson:"created_at"`
+	UpdatedAt    time.Time           `json:"updated_at" bson:"updated_at"`
+}
+
+type CreateReferralRequest struct {
+	JobID     string `json:"job_id" validate:"required"`
+	AlumnusID string `json:"alumnus_id" validate:"required"`
+	Note      string `json:"note" validate:"required,min=20,max=2000"`
+	ResumeID  string `json:"resume_id,omitempty"`
+}
+
+type RespondReferralRequest struct {
+	Note string `json:"note,omitempty" validate:"omitempty,max=1000"`
+}
+
+type UpdateReferralOutcomeRequest struct {
+	Outcome ReferralOutcome `json:"outcome" validate:"required,oneof=referred interviewing hired not_selected"`
+}
@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+// RepoMetadata is what we know about a project's source repository. It is
+// refreshed in the background from the repository host.
+type RepoMetadata struct {
+	Provider        string         `json:"provider" bson:"provider"`
+	FullName        string         `json:"full_name" bson:"full_name"`
+	Description     string         `json:"description,omitempty" bson:"description,omitempty"`
+	Stars           int            `json:"stars" bson:"stars"`
+	Forks           int            `json:"forks" bson:"forks"`
+	PrimaryLanguage string         `json:"primary_language,omitempty" bson:"primary_language,omitempty"`
+	Languages       []RepoLanguage `json:"languages,omitempty" bson:"languages,omitempty"`
+	ReadmeExcerpt   string         `json:"readme_excerpt,omitempty" bson:"readme_excerpt,omitempty"`
+	LastCommitAt    *time.Time     `json:"last_commit_at,omitempty" bson:"last_commit_at,omitempty"`
+	FetchedAt       time.Time      `json:"fetched_at" bson:"fetched_at"`
+	FetchError      string         `json:"fetch_error,omitempty" bson:"fetch_error,omitempty"`
+}
+
+// RepoLanguage is a language's share of the repository's code
+type RepoLanguage struct {
+	Name    string  `json:"name" bson:"name"`
+	Bytes   int64   `json:"bytes" bson:"bytes"`
+	Percent float64 `json:"percent" bson:"percent"`
+}
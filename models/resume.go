@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Resume is one uploaded version of a user's CV. Files are stored outside the
+// public directory and are only served through the authenticated download route.
+type Resume struct {
+	ID          primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	UserID      primitive.ObjectID `json:"user_id" bson:"user_id"`
+	Version     int                `json:"version" bson:"version"`
+	FileName    string             `json:"file_name" bson:"file_name"`
+	StoredName  string             `json:"-" bson:"stored_name"`
+	ContentType string             `json:"content_type" bson:"content_type"`
+	Size        int64              `json:"size" bson:"size"`
+	CreatedAt   time.Time          `json:"created_at" bson:"created_at"`
+}
@@ -0,0 +1,86 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type AlertFrequency string
+
+const (
+	AlertInstant AlertFrequency = "instant"
+	AlertDaily   AlertFrequency = "daily"
+)
+
+// MaxSavedJobSearches is how many saved searches a user can keep
+const MaxSavedJobSearches = 10
+
+// SavedJobSearch is a job search a user is alerted about. Empty criteria
+// match every job.
+type SavedJobSearch struct {
+	ID           primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	UserID       primitive.ObjectID `json:"user_id" bson:"user_id"`
+	Name         string             `json:"name" bson:"name"`
+	JobType      JobType            `json:"job_type,omitempty" bson:"job_type,omitempty"`
+	Location     string             `json:"location,omitempty" bson:"location,omitempty"`
+	Keywords     []string           `json:"keywords" bson:"keywords"`
+	Skills       []string           `json:"skills" bson:"skills"`
+	Frequency    AlertFrequency     `json:"frequency" bson:"frequency"`
+	LastDigestAt *time.Time         `json:"last_digest_at,omitempty" bson:"last_digest_at,omitempty"`
+	CreatedAt    time.Time          `json:"created_at" bson:"created_at"`
+	UpdatedAt    time.Time          `json:"updated_at" bson:"updated_at"`
+}
+
+// Matches reports whether a job fits the search. Every keyword must appear
+// in the job's title, company, description or requirements; at least one
+// skill must appear as a whole word in its requirements or description, so
+// "Go" does not match "good".
+func (s *SavedJobSearch) Matches(job *Job) bool {
+	if s.JobType != "" && s.JobType != job.JobType {
+		return false
+	}
+	if s.Location != "" && !strings.Contains(strings.ToLower(job.Location), strings.ToLower(s.Location)) {
+		return false
+	}
+
+	requirements := strings.ToLower(strings.Join(job.Requirements, "\n"))
+	description := strings.ToLower(job.Description)
+	text := strings.ToLower(job.Title+"\n"+job.Company) + "\n" + description + "\n" + requirements
+
+	for _, keyword := range s.Keywords {
+		if !strings.Contains(text, strings.ToLower(keyword)) {
+			return false
+		}
+	}
+
+	if len(s.Skills) == 0 {
+		return true
+	}
+	for _, skill := range s.Skills {
+		skill = strings.ToLower(skill)
+		if containsTerm(requirements, skill) || containsTerm(description, skill) {
+			return true
+		}
+	}
+	return false
+}
+
+type CreateSavedJobSearchRequest struct {
+	Name      string         `json:"name" validate:"required,min=2,max=100"`
+	JobType   JobType        `json:"job_type,omitempty" validate:"omitempty,oneof=full-time part-time internship contract"`
+	Location  string         `json:"location,omitempty" validate:"omitempty,max=100"`
+	Keywords  []string       `json:"keywords,omitempty" validate:"omitempty,max=10,dive,min=2,max=50"`
+	Skills    []string       `json:"skills,omitempty" validate:"omitempty,max=20,dive,min=1,max=50"`
+	Frequency AlertFrequency `json:"frequency" validate:"required,oneof=instant daily"`
+}
+
+type UpdateSavedJobSearchRequest struct {
+	Name      string         `json:"name,omitempty" validate:"omitempty,min=2,max=100"`
+	JobType   *JobType       `json:"job_type,omitempty" validate:"omitempty,oneof=full-time part-time internship contract ''"`
+	Location  *string        `json:"location,omitempty" validate:"omitempty,max=100"`
+	Keywords  []string       `json:"keywords,omitempty" validate:"omitempty,max=10,dive,min=2,max=50"`
+	Skills    []string       `json:"skills,omitempty" validate:"omitempty,max=20,dive,min=1,max=50"`
+	Frequency AlertFrequency `json:"frequency,omitempty" validate:"omitempty,oneof=instant daily"`
+}
@@ -0,0 +1,50 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Showcase is a curated, ordered collection of approved projects, such as
+// "Best Major Projects 2025". Only published showcases are public.
+type Showcase struct {
+	ID            primitive.ObjectID   `json:"id" bson:"_id,omitempty"`
+	Title         string               `json:"title" bson:"title"`
+	Description   string               `json:"description" bson:"description"`
+	CoverImageURL string               `json:"cover_image_url,omitempty" bson:"cover_image_url,omitempty"`
+	ProjectIDs    []primitive.ObjectID `json:"project_ids" bson:"project_ids"`
+	Projects      []Project            `json:"projects,omitempty" bson:"-"`
+	ProjectCount  int                  `json:"project_count" bson:"-"`
+	IsPublished   bool                 `json:"is_published" bson:"is_published"`
+	CreatedBy     primitive.ObjectID   `json:"created_by" bson:"created_by"`
+	UpdatedBy     primitive.ObjectID   `json:"updated_by" bson:"updated_by"`
+	IsActive      bool                 `json:"is_active" bson:"is_active"`
+	CreatedAt     time.Time            `json:"created_at" bson:"created_at"`
+	UpdatedAt     time.Time            `json:"updated_at" bson:"updated_at"`
+}
+
+type CreateShowcaseRequest struct {
+	Title         string   `json:"title" validate:"required,min=3,max=200"`
+	Description   string   `json:"description" validate:"max=2000"`
+	CoverImageURL string   `json:"cover_image_url,omitempty" validate:"omitempty,max=500"`
+	ProjectIDs    []string `json:"project_ids,omitempty" validate:"omitempty,max=100"`
+	IsPublished   bool     `json:"is_published"`
+}
+
+type UpdateShowcaseRequest struct {
+	Title         string  `json:"title,omitempty" validate:"omitempty,min=3,max=200"`
+	Description   *string `json:"description,omitempty" validate:"omitempty,max=2000"`
+	CoverImageURL *string `json:"cover_image_url,omitempty" validate:"omitempty,max=500"`
+	IsPublished   *bool   `json:"is_published,omitempty"`
+}
+
+// SetShowcaseProjectsRequest replaces a showcase's projects; the order of
+// ProjectIDs is the display order
+type SetShowcaseProjectsRequest struct {
+	ProjectIDs []string `json:"project_ids" validate:"max=100"`
+}
+
+type FeatureProjectRequest struct {
+	IsFeatured bool `json:"is_featured"`
+}
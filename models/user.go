@@ -15,6 +15,40 @@ const (
 	RoleAdmin   UserRole = "admin"
 )
 
+type Visibility string
+
+const (
+	VisibilityPublic      Visibility = "public"
+	VisibilityConnections Visibility = "connections"
+	VisibilityPrivate     Visibility = "private"
+)
+
+// Allows reports whether data with this visibility can be shown to a viewer.
+// An unset visibility is treated as public.
+func (v Visibility) Allows(isSelf, isConnected bool) bool {
+	switch v {
+	case VisibilityPrivate:
+		return isSelf
+	case VisibilityConnections:
+		return isSelf || isConnected
+	default:
+		return true
+	}
+}
+
+type PrivacySettings struct {
+	ProfileVisibility Visibility `json:"profile_visibility,omitempty" bson:"profile_visibility,omitempty" validate:"omitempty,oneof=public connections private"`
+	EmailVisibility   Visibility `json:"email_visibility,omitempty" bson:"email_visibility,omitempty" validate:"omitempty,oneof=public connections private"`
+	ContactVisibility Visibility `json:"contact_visibility,omitempty" bson:"contact_visibility,omitempty" validate:"omitempty,oneof=public connections private"`
+	AllowMessagesFrom Visibility `json:"allow_messages_from,omitempty" bson:"allow_messages_from,omitempty" validate:"omitempty,oneof=public connections"`
+	HideProfileViews  *bool      `json:"hide_profile_views,omitempty" bson:"hide_profile_views,omitempty"`
+}
+
+// ViewsHidden reports whether the user browses profiles anonymously
+func (p PrivacySettings) ViewsHidden() bool {
+	return p.HideProfileViews != nil && *p.HideProfileViews
+}
+
 type User struct {
 	ID             primitive.ObjectID `json:"id" bson:"_id,omitempty"`
 	Name           string             `json:"name" bson:"name" validate:"required,min=2,max=100"`
@@ -33,10 +67,18 @@ type User struct {
 	GitHubURL      string             `json:"github_url,omitempty" bson:"github_url,omitempty" validate:"omitempty,url"`
 	LinkedInURL    string             `json:"linkedin_url,omitempty" bson:"linkedin_url,omitempty" validate:"omitempty,url"`
 	AvatarURL      string             `json:"avatar_url,omitempty" bson:"avatar_url,omitempty"`
+	Endorsements   int                `json:"endorsements_count" bson:"endorsements_count"`
+	Privacy        PrivacySettings    `json:"-" bson:"privacy"`
 	IsVerified     bool               `json:"is_verified" bson:"is_verified"`
 	IsActive       bool               `json:"is_active" bson:"is_active"`
 	CreatedAt      time.Time          `json:"created_at" bson:"created_at"`
 	UpdatedAt      time.Time          `json:"updated_at" bson:"updated_at"`
+
+	DeletionScheduledAt *time.Time `json:"deletion_scheduled_at,omitempty" bson:"deletion_scheduled_at,omitempty"`
+	DeletedAt           *time.Time `json:"deleted_at,omitempty" bson:"deleted_at,omitempty"`
+
+	ProfileCompleteness  int        `json:"profile_completeness" bson:"profile_completeness"`
+	CompletenessNudgedAt *time.Time `json:"-" bson:"completeness_nudged_at,omitempty"`
 }
 
 type RegisterRequest struct {
@@ -79,16 +121,22 @@ type UpdateProfileRequest struct {
 	Name           string   `json:"name,omitempty" validate:"omitempty,min=2,max=100"`
 	Company        string   `json:"company,omitempty"`
 	Position       string   `json:"position,omitempty"`
-	CGPA           float64  `json:"cgpa,omitempty"`
+	CGPA           float64  `json:"cgpa,omitempty" validate:"omitempty,min=0,max=10"`
 	Experience     string   `json:"experience,omitempty"`
 	Location       string   `json:"location,omitempty"`
 	Skills         []string `json:"skills,omitempty"`
-	Bio            string   `json:"bio,omitempty"`
+	Bio            string   `json:"bio,omitempty" validate:"omitempty,max=1000"`
 	AvatarURL      string   `json:"avatar_url,omitempty" validate:"omitempty,url"`
-	StudentID      string   `json:"student_id,omitempty"`
+	StudentID      string   `json:"student_id,omitempty" validate:"omitempty,max=50"`
 	GraduationYear int      `json:"graduation_year,omitempty" validate:"omitempty,min=2000,max=2030"`
 	GitHubURL      string   `json:"github_url,omitempty" validate:"omitempty,url"`
 	LinkedInURL    string   `json:"linkedin_url,omitempty" validate:"omitempty,url"`
+
+	Privacy *PrivacySettings `json:"privacy,omitempty"`
+}
+
+type DeleteAccountRequest struct {
+	Password string `json:"password" validate:"required"`
 }
 
 type UserResponse struct {
@@ -107,8 +155,17 @@ type UserResponse struct {
 	GitHubURL      string             `json:"github_url,omitempty"`
 	LinkedInURL    string             `json:"linkedin_url,omitempty"`
 	AvatarURL      string             `json:"avatar_url,omitempty"`
+	Endorsements   int                `json:"endorsements_count"`
 	IsVerified     bool               `json:"is_verified"`
 	CreatedAt      time.Time          `json:"created_at"`
+
+	SkillEndorsements []SkillEndorsementSummary `json:"skill_endorsements,omitempty"`
+	Privacy           *PrivacySettings          `json:"privacy,omitempty"`
+	Relationship      *Relationship             `json:"relationship,omitempty"`
+	ProfileRestricted bool                      `json:"profile_restricted,omitempty"`
+
+	DeletionScheduledAt *time.Time           `json:"deletion_scheduled_at,omitempty"`
+	Completeness        *ProfileCompleteness `json:"completeness,omitempty"`
 }
 
 func (u *User) ToResponse() *UserResponse {
@@ -128,7 +185,38 @@ func (u *User) ToResponse() *UserResponse {
 		GitHubURL:      u.GitHubURL,
 		LinkedInURL:    u.LinkedInURL,
 		AvatarURL:      u.AvatarURL,
+		Endorsements:   u.Endorsements,
 		IsVerified:     u.IsVerified,
 		CreatedAt:      u.CreatedAt,
 	}
 }
+
+// ToResponseFor returns the profile as seen by viewerID, hiding whatever the
+// owner's privacy settings do not share with that viewer.
+func (u *User) ToResponseFor(viewerID primitive.ObjectID, isConnected bool) *UserResponse {
+	isSelf := u.ID == viewerID
+
+	if !u.Privacy.ProfileVisibility.Allows(isSelf, isConnected) {
+		return &UserResponse{
+			ID:                u.ID,
+			Name:              u.Name,
+			Role:              u.Role,
+			AvatarURL:         u.AvatarURL,
+			IsVerified:        u.IsVerified,
+			CreatedAt:         u.CreatedAt,
+			ProfileRestricted: true,
+		}
+	}
+
+	response := u.ToResponse()
+	if !u.Privacy.EmailVisibility.Allows(isSelf, isConnected) {
+		response.Email = ""
+	}
+	if !u.Privacy.ContactVisibility.Allows(isSelf, isConnected) {
+		response.Location = ""
+		response.GitHubURL = ""
+		response.LinkedInURL = ""
+	}
+
+	return response
+}
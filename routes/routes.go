@@ -260,6 +260,25 @@ func SetupRoutes(app fiber.Router) {
 	auth.Post("/refresh", middleware.RateLimit("refresh", cfg.RateLimitRefresh), authHandler.RefreshToken)
 	auth.Post("/logout", authHandler.Logout)
 
+	// One-click job extension from expiry reminder emails
+	app.Post("/jobs/extend", handlers.NewJobHandler().ExtendJob)
+
+	// Project showcases are public; curation is limited to faculty and admins
+	showcases := app.Group("/showcases")
+	showcaseHandler := handlers.NewShowcaseHandler()
+	curator := []fiber.Handler{middleware.AuthRequired(), middleware.RoleRequired(models.RoleFaculty, models.RoleAdmin)}
+	showcases.Get("/", showcaseHandler.GetShowcases)
+	showcases.Get("/all", append(curator, showcaseHandler.GetAllShowcases)...)
+	showcases.Get("/:id", showcaseHandler.GetShowcase)
+	showcases.Post("/", append(curator, showcaseHandler.CreateShowcase)...)
+	showcases.Put("/:id", append(curator, showcaseHandler.UpdateShowcase)...)
+	showcases.Put("/:id/projects", append(curator, showcaseHandler.SetShowcaseProjects)...)
+	showcases.Delete("/:id", append(curator, showcaseHandler.DeleteShowcase)...)
+
+	// Project attachments are streamed with the project's visibility rules;
+	// anonymous requests only see media of approved projects
+	app.Get("/projects/:id/media/:mediaId/file", middleware.OptionalAuth(), handlers.NewProjectHandler().DownloadProjectMedia)
+
 	// Protected routes
 	api := app.Group("", middleware.AuthRequired())
 
@@ -269,31 +288,174 @@ func SetupRoutes(app fiber.Router) {
 	users.Put("/updateprofile", userHandler.UpdateProfile)
 	users.Get("/getusers", userHandler.GetUsers)
 	users.Get("/dashboard-stats", userHandler.GetDashboardStats)
+	recommendationHandler := handlers.NewRecommendationHandler()
+	users.Get("/recommendations", recommendationHandler.GetPeopleYouMayKnow)
+	users.Get("/profile-views", userHandler.GetProfileViews)
+	users.Get("/email-preferences", userHandler.GetEmailPreferences)
+	users.Put("/email-preferences", userHandler.UpdateEmailPreferences)
 	users.Get("/:id", userHandler.GetUserByID)
 
+	// Skill endorsement routes
+	endorsementHandler := handlers.NewEndorsementHandler()
+	users.Get("/:id/endorsements", endorsementHandler.GetEndorsements)
+	users.Post("/:id/endorsements", endorsementHandler.EndorseSkill)
+	users.Delete("/:id/endorsements/:skill", endorsementHandler.RemoveEndorsement)
+
+	// Follow routes
+	connectionHandler := handlers.NewConnectionHandler()
+	users.Post("/:id/follow", connectionHandler.FollowUser)
+	users.Delete("/:id/follow", connectionHandler.UnfollowUser)
+	users.Get("/:id/followers", connectionHandler.GetFollowers)
+	users.Get("/:id/following", connectionHandler.GetFollowing)
+
+	// Profile export routes
+	exportHandler := handlers.NewExportHandler()
+	users.Get("/:id/export/json-resume", exportHandler.ExportJSONResume)
+	users.Get("/:id/export/vcard", exportHandler.ExportVCard)
+	users.Get("/:id/export/pdf", exportHandler.ExportPDF)
+
+	// Connection routes
+	connections := api.Group("/connections")
+	connections.Get("/", connectionHandler.GetConnections)
+	connections.Get("/requests", connectionHandler.GetConnectionRequests)
+	connections.Put("/requests/:id/accept", connectionHandler.AcceptConnection)
+	connections.Put("/requests/:id/decline", connectionHandler.DeclineConnection)
+	connections.Get("/:id/status", connectionHandler.GetRelationship)
+	connections.Get("/:id/mutual", connectionHandler.GetMutualConnections)
+	connections.Post("/:id", connectionHandler.SendConnectionRequest)
+	connections.Delete("/:id", connectionHandler.RemoveConnection)
+
+	// Account routes
+	account := api.Group("/account")
+	accountHandler := handlers.NewAccountHandler()
+	account.Get("/export", accountHandler.ExportData)
+	account.Delete("/", accountHandler.RequestDeletion)
+	account.Post("/deletion/cancel", accountHandler.CancelDeletion)
+
+	// Cohort routes
+	cohorts := api.Group("/cohorts")
+	cohortHandler := handlers.NewCohortHandler()
+	cohorts.Get("/", cohortHandler.GetCohorts)
+	cohorts.Get("/:year", cohortHandler.GetCohort)
+	cohorts.Get("/:year/members", cohortHandler.GetCohortMembers)
+	cohorts.Get("/:year/announcements", cohortHandler.GetAnnouncements)
+	cohorts.Post("/:year/announcements", cohortHandler.CreateAnnouncement)
+	cohorts.Delete("/:year/announcements/:id", cohortHandler.DeleteAnnouncement)
+	cohorts.Post("/:year/representatives", middleware.RoleRequired(models.RoleAdmin), cohortHandler.AssignRepresentative)
+	cohorts.Delete("/:year/representatives/:userId", middleware.RoleRequired(models.RoleAdmin), cohortHandler.RemoveRepresentative)
+
+	// Mentorship routes
+	mentorship := api.Group("/mentorship")
+	mentorshipHandler := handlers.NewMentorshipHandler()
+	mentorship.Get("/profile", middleware.RoleRequired(models.RoleAlumni), mentorshipHandler.GetMyMentorProfile)
+	mentorship.Put("/profile", middleware.RoleRequired(models.RoleAlumni), mentorshipHandler.UpsertMentorProfile)
+	mentorship.Delete("/profile", middleware.RoleRequired(models.RoleAlumni), mentorshipHandler.DeleteMentorProfile)
+	mentorship.Get("/mentors", mentorshipHandler.GetMentors)
+	mentorship.Get("/matches", middleware.RoleRequired(models.RoleStudent), mentorshipHandler.GetMentorMatches)
+	mentorship.Get("/requests", mentorshipHandler.GetMentorships)
+	mentorship.Post("/requests", middleware.RoleRequired(models.RoleStudent), mentorshipHandler.RequestMentorship)
+	mentorship.Put("/requests/:id/accept", middleware.RoleRequired(models.RoleAlumni), mentorshipHandler.AcceptMentorship)
+	mentorship.Put("/requests/:id/decline", middleware.RoleRequired(models.RoleAlumni), mentorshipHandler.DeclineMentorship)
+	mentorship.Put("/requests/:id/complete", mentorshipHandler.CompleteMentorship)
+	mentorship.Put("/requests/:id/cancel", middleware.RoleRequired(models.RoleStudent), mentorshipHandler.CancelMentorship)
+
 	// Project routes
 	projects := api.Group("/projects")
 	projectHandler := handlers.NewProjectHandler()
 	projects.Get("/projectview", projectHandler.GetProjects)
 	projects.Post("/addproject", middleware.RoleRequired(models.RoleStudent), projectHandler.CreateProject)
+	projects.Get("/invitations", projectHandler.GetInvitations)
+	projects.Get("/review-queue", middleware.RoleRequired(models.RoleFaculty, models.RoleAdmin), projectHandler.GetReviewQueue)
+	projects.Get("/comments/reported", middleware.RoleRequired(models.RoleAdmin), projectHandler.GetReportedComments)
 	projects.Get("/:id", projectHandler.GetProjectByID)
 	projects.Put("/:id", middleware.RoleRequired(models.RoleStudent), projectHandler.UpdateProject)
 	projects.Delete("/:id", middleware.RoleRequired(models.RoleStudent, models.RoleAdmin), projectHandler.DeleteProject)
 	projects.Post("/:id/like", projectHandler.LikeProject)
 	projects.Delete("/:id/like", projectHandler.UnlikeProject)
+	projects.Put("/:id/featured", middleware.RoleRequired(models.RoleFaculty, models.RoleAdmin), projectHandler.FeatureProject)
+
+	// Project contributors
+	projects.Post("/:id/contributors", middleware.RoleRequired(models.RoleStudent), projectHandler.InviteContributor)
+	projects.Put("/:id/contributors/accept", projectHandler.AcceptInvitation)
+	projects.Put("/:id/contributors/decline", projectHandler.DeclineInvitation)
+	projects.Put("/:id/contributors/:userId", middleware.RoleRequired(models.RoleStudent), projectHandler.UpdateContributor)
+	projects.Delete("/:id/contributors/:userId", projectHandler.RemoveContributor)
+
+	// Project review workflow
+	projects.Post("/:id/submit", projectHandler.SubmitProject)
+	projects.Put("/:id/reviewer", middleware.RoleRequired(models.RoleFaculty, models.RoleAdmin), projectHandler.AssignReviewer)
+	projects.Get("/:id/reviews", projectHandler.GetProjectReviews)
+	projects.Post("/:id/reviews", middleware.RoleRequired(models.RoleFaculty), projectHandler.SubmitReview)
+
+	// Project version history
+	projects.Get("/:id/versions", projectHandler.GetProjectVersions)
+	projects.Get("/:id/versions/diff", projectHandler.DiffProjectVersions)
+	projects.Get("/:id/versions/:version", projectHandler.GetProjectVersion)
+	projects.Post("/:id/versions/:version/restore", projectHandler.RestoreProjectVersion)
+
+	// Project analytics
+	projects.Get("/:id/analytics", projectHandler.GetProjectAnalytics)
+
+	// Repository metadata
+	projects.Post("/:id/repo-metadata/refresh", projectHandler.RefreshProjectRepoMetadata)
+
+	// Project media
+	projects.Get("/:id/media", projectHandler.GetProjectMedia)
+	projects.Put("/:id/media/order", projectHandler.ReorderProjectMedia)
+	projects.Put("/:id/media/:mediaId", projectHandler.UpdateProjectMedia)
+	projects.Put("/:id/media/:mediaId/cover", projectHandler.SetProjectCover)
+	projects.Delete("/:id/media/:mediaId", projectHandler.DeleteProjectMedia)
+
+	// Project comments
+	projects.Get("/:id/comments", projectHandler.GetComments)
+	projects.Post("/:id/comments", projectHandler.CreateComment)
+	projects.Put("/:id/comments/:commentId", projectHandler.UpdateComment)
+	projects.Delete("/:id/comments/:commentId", projectHandler.DeleteComment)
+	projects.Post("/:id/comments/:commentId/report", projectHandler.ReportComment)
+	projects.Put("/:id/comments/:commentId/moderate", projectHandler.ModerateComment)
 
 	// Job routes
 	jobs := api.Group("/jobs")
 	jobHandler := handlers.NewJobHandler()
 	jobs.Get("/", jobHandler.GetJobs)
 	jobs.Post("/add", middleware.RoleRequired(models.RoleAlumni), jobHandler.CreateJob)
+	jobs.Get("/applications/mine", middleware.RoleRequired(models.RoleStudent), jobHandler.GetMyApplications)
+	jobs.Get("/saved-searches", jobHandler.GetSavedSearches)
+	jobs.Post("/saved-searches", jobHandler.CreateSavedSearch)
+	jobs.Put("/saved-searches/:searchId", jobHandler.UpdateSavedSearch)
+	jobs.Delete("/saved-searches/:searchId", jobHandler.DeleteSavedSearch)
 	jobs.Get("/:id", jobHandler.GetJobByID)
 	jobs.Put("/:id", middleware.RoleRequired(models.RoleAlumni), jobHandler.UpdateJob)
 	jobs.Delete("/:id", middleware.RoleRequired(models.RoleAlumni, models.RoleAdmin), jobHandler.DeleteJob)
-	jobs.Post("/:id/interest", middleware.RoleRequired(models.RoleStudent), jobHandler.ShowInterest)
-	jobs.Delete("/:id/interest", middleware.RoleRequired(models.RoleStudent), jobHandler.RemoveInterest)
+
+	// Job applications; the interest routes are kept for older clients
+	jobs.Post("/:id/apply", middleware.RoleRequired(models.RoleStudent), jobHandler.ApplyToJob)
+	jobs.Delete("/:id/apply", middleware.RoleRequired(models.RoleStudent), jobHandler.WithdrawApplication)
+	jobs.Get("/:id/applications", middleware.RoleRequired(models.RoleAlumni, models.RoleAdmin), jobHandler.GetJobApplications)
+	jobs.Put("/:id/applications/:applicationId/status", middleware.RoleRequired(models.RoleAlumni, models.RoleAdmin), jobHandler.UpdateApplicationStatus)
+	jobs.Post("/:id/interest", middleware.RoleRequired(models.RoleStudent), jobHandler.ApplyToJob)
+	jobs.Delete("/:id/interest", middleware.RoleRequired(models.RoleStudent), jobHandler.WithdrawApplication)
 	jobs.Get("/:id/interested-users", middleware.RoleRequired(models.RoleAlumni, models.RoleAdmin), jobHandler.GetInterestedUsers)
 
+	// Referral requests
+	referrals := api.Group("/referrals")
+	referralHandler := handlers.NewReferralHandler()
+	jobs.Get("/:id/referrers", middleware.RoleRequired(models.RoleStudent), referralHandler.GetReferrers)
+	referrals.Get("/", referralHandler.GetReferrals)
+	referrals.Post("/", middleware.RoleRequired(models.RoleStudent), referralHandler.CreateReferral)
+	referrals.Put("/:id/accept", middleware.RoleRequired(models.RoleAlumni), referralHandler.AcceptReferral)
+	referrals.Put("/:id/decline", middleware.RoleRequired(models.RoleAlumni), referralHandler.DeclineReferral)
+	referrals.Put("/:id/cancel", middleware.RoleRequired(models.RoleStudent), referralHandler.CancelReferral)
+	referrals.Put("/:id/outcome", referralHandler.UpdateReferralOutcome)
+
+	// Anonymous salary reports and insights
+	salaries := api.Group("/salaries")
+	salaryHandler := handlers.NewSalaryHandler()
+	salaries.Get("/insights", salaryHandler.GetSalaryInsights)
+	salaries.Get("/mine", salaryHandler.GetMySalaryReports)
+	salaries.Post("/", middleware.RoleRequired(models.RoleAlumni), salaryHandler.SubmitSalaryReport)
+	salaries.Delete("/:id", salaryHandler.DeleteSalaryReport)
+
 	// Event routes
 	events := api.Group("/events")
 	eventHandler := handlers.NewEventHandler()
@@ -393,6 +555,13 @@ func SetupRoutes(app fiber.Router) {
 	uploadHandler := handlers.NewUploadHandler()
 	upload.Post("/avatar", uploadHandler.UploadAvatar)
 	upload.Post("/gallery", middleware.RoleRequired(models.RoleFaculty, models.RoleAlumni, models.RoleStudent), uploadHandler.UploadGalleryImage)
+	upload.Post("/resume", uploadHandler.UploadResume)
+	upload.Delete("/resume/:id", uploadHandler.DeleteResume)
+	upload.Post("/projects/:id/media", uploadHandler.UploadProjectMedia)
+
+	// Resume downloads are authenticated and access checked, never static
+	users.Get("/:id/resumes", uploadHandler.GetResumes)
+	users.Get("/:id/resumes/:resumeId", uploadHandler.DownloadResume)
 
 	// Static file serving
 	app.Static("/avatars", "./public/avatars")
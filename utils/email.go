@@ -218,6 +218,112 @@ Need help? Contact us at support@almaniportal.com`, senderName, messageSubject,
 	return nil
 }
 
+// SendProfileNudge - Remind users to complete their profile
+func (e *EmailService) SendProfileNudge(to, name string, score int, missing []string) error {
+	subject := "📝 Complete Your Profile - ETE Alumni Portal"
+
+	items := ""
+	for _, item := range missing {
+		items += "• " + item + "\n"
+	}
+
+	body := fmt.Sprintf(`Dear %s,
+
+Your ETE Alumni Portal profile is %d%% complete. 📊
+
+A complete profile helps classmates, alumni and recruiters find you. Still missing:
+
+%s
+🔗 Login to the portal to update your profile:
+%s/profile
+
+Best regards,
+ETE Alumni Portal Team
+Dr. Ambedkar Institute of Technology, Bengaluru
+
+---
+Need help? Contact us at support@almaniportal.com`, name, score, items, e.config.FrontendURL)
+
+	err := e.sendEmail(to, subject, body)
+	if err != nil {
+		e.logEmail(models.EmailTypeProfileNudge, to, subject, "failed", err.Error())
+		return err
+	}
+
+	e.logEmail(models.EmailTypeProfileNudge, to, subject, "sent", "")
+	return nil
+}
+
+// SendJobExpiryReminder - Remind posters that a job is about to expire
+func (e *EmailService) SendJobExpiryReminder(to, name, jobTitle, company string, expiresAt time.Time, extendURL string) error {
+	subject := fmt.Sprintf("⏰ Your job posting expires soon - %s at %s", jobTitle, company)
+	body := fmt.Sprintf(`Dear %s,
+
+Your job posting on the ETE Alumni Portal will expire on %s. 📅
+
+📋 Job Title: %s
+🏢 Company: %s
+
+Still hiring? Extend the posting with one click:
+%s
+
+If the position is filled, no action is needed. The posting will be
+hidden from listings once it expires.
+
+Best regards,
+ETE Alumni Portal Team
+Dr. Ambedkar Institute of Technology, Bengaluru
+
+---
+Need help? Contact us at support@almaniportal.com`, name, expiresAt.Format("January 2, 2006"), jobTitle, company, extendURL)
+
+	err := e.sendEmail(to, subject, body)
+	if err != nil {
+		e.logEmail(models.EmailTypeJobExpiry, to, subject, "failed", err.Error())
+		return err
+	}
+
+	e.logEmail(models.EmailTypeJobExpiry, to, subject, "sent", "")
+	return nil
+}
+
+// SendJobAlertDigest - Send the daily list of jobs matching saved searches
+func (e *EmailService) SendJobAlertDigest(to, name string, jobs []models.Job) error {
+	subject := fmt.Sprintf("💼 %d new jobs match your saved searches", len(jobs))
+	if len(jobs) == 1 {
+		subject = "💼 1 new job matches your saved searches"
+	}
+
+	items := ""
+	for _, job := range jobs {
+		items += fmt.Sprintf("• %s at %s (%s)\n  %s/jobs/%s\n", job.Title, job.Company, job.Location, e.config.FrontendURL, job.ID.Hex())
+	}
+
+	body := fmt.Sprintf(`Dear %s,
+
+These jobs were posted on the ETE Alumni Portal since your last alert: 🎯
+
+%s
+🔔 Manage your saved searches and alert frequency:
+%s/jobs/saved-searches
+
+Best regards,
+ETE Alumni Portal Team
+Dr. Ambedkar Institute of Technology, Bengaluru
+
+---
+Need help? Contact us at support@almaniportal.com`, name, items, e.config.FrontendURL)
+
+	err := e.sendEmail(to, subject, body)
+	if err != nil {
+		e.logEmail(models.EmailTypeJobAlert, to, subject, "failed", err.Error())
+		return err
+	}
+
+	e.logEmail(models.EmailTypeJobAlert, to, subject, "sent", "")
+	return nil
+}
+
 // SendTestEmail - Test email functionality
 func (e *EmailService) SendTestEmail(to, subject, body string) error {
 	return e.sendEmail(to, subject, body)
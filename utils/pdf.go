@@ -0,0 +1,163 @@
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// PDFDocument is a minimal text-only PDF writer used for printable exports.
+// It lays out text top to bottom on A4 pages using the built-in Helvetica
+// fonts, so no font files or third party libraries are needed.
+type PDFDocument struct {
+	pages   []*bytes.Buffer
+	current *bytes.Buffer
+	y       float64
+}
+
+const (
+	pdfPageWidth  = 595.0
+	pdfPageHeight = 842.0
+	pdfMargin     = 50.0
+)
+
+func NewPDFDocument() *PDFDocument {
+	doc := &PDFDocument{}
+	doc.newPage()
+	return doc
+}
+
+func (d *PDFDocument) newPage() {
+	d.current = &bytes.Buffer{}
+	d.pages = append(d.pages, d.current)
+	d.y = pdfPageHeight - pdfMargin
+}
+
+// Title writes a large bold line
+func (d *PDFDocument) Title(text string) {
+	d.write(text, "F2", 20)
+}
+
+// Heading writes a bold section heading with some space above it
+func (d *PDFDocument) Heading(text string) {
+	d.Space(8)
+	d.write(text, "F2", 13)
+}
+
+// Text writes a paragraph, wrapping it to the page width
+func (d *PDFDocument) Text(text string) {
+	d.write(text, "F1", 10)
+}
+
+// Space adds vertical whitespace
+func (d *PDFDocument) Space(points float64) {
+	d.y -= points
+}
+
+func (d *PDFDocument) write(text, font string, size float64) {
+	lineHeight := size * 1.4
+	// Helvetica averages roughly half an em per character
+	maxChars := int((pdfPageWidth - 2*pdfMargin) / (size * 0.5))
+
+	for _, paragraph := range strings.Split(text, "\n") {
+		for _, line := range wrapText(paragraph, maxChars) {
+			if d.y-lineHeight < pdfMargin {
+				d.newPage()
+			}
+			d.y -= lineHeight
+			fmt.Fprintf(d.current, "BT /%s %.0f Tf %.2f %.2f Td (%s) Tj ET\n", font, size, pdfMargin, d.y, escapePDFText(line))
+		}
+	}
+}
+
+// Bytes renders the document
+func (d *PDFDocument) Bytes() []byte {
+	var out bytes.Buffer
+	var offsets []int
+
+	addObject := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	out.WriteString("%PDF-1.4\n")
+
+	// Objects 1-4 are the catalog, page tree and fonts; each page then takes
+	// two objects, the page itself and its content stream.
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 5+i*2)
+	}
+
+	addObject("<< /Type /Catalog /Pages 2 0 R >>")
+	addObject(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	addObject("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	addObject("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+
+	for i, page := range d.pages {
+		addObject(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.0f %.0f] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			pdfPageWidth, pdfPageHeight, 6+i*2))
+		addObject(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", page.Len(), page.String()))
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	return out.Bytes()
+}
+
+func wrapText(text string, maxChars int) []string {
+	words := strings.Fields(text)
+	if len(words) == 0 {
+		return []string{""}
+	}
+
+	var lines []string
+	line := ""
+	for _, word := range words {
+		for runes := []rune(word); len(runes) > maxChars; runes = []rune(word) {
+			if line != "" {
+				lines = append(lines, line)
+				line = ""
+			}
+			lines = append(lines, string(runes[:maxChars]))
+			word = string(runes[maxChars:])
+		}
+		switch {
+		case line == "":
+			line = word
+		case len([]rune(line))+1+len([]rune(word)) > maxChars:
+			lines = append(lines, line)
+			line = word
+		default:
+			line += " " + word
+		}
+	}
+	return append(lines, line)
+}
+
+// escapePDFText escapes string delimiters and replaces characters outside
+// the Latin-1 range, which the standard fonts cannot render.
+func escapePDFText(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteRune('\\')
+			b.WriteRune(r)
+		case r < 32:
+			b.WriteRune(' ')
+		case r > 255:
+			b.WriteRune('?')
+		case r > 126:
+			fmt.Fprintf(&b, "\\%03o", r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
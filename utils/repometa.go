@@ -0,0 +1,210 @@
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"ete-alumni-portal/config"
+	"ete-alumni-portal/models"
+)
+
+// ErrUnsupportedRepo is returned for repository URLs a fetcher cannot handle
+var ErrUnsupportedRepo = errors.New("unsupported repository URL")
+
+// readmeExcerptLength is the maximum length of the stored README excerpt
+const readmeExcerptLength = 500
+
+// RepoMetadataFetcher loads metadata for a project's repository URL
+type RepoMetadataFetcher interface {
+	Fetch(ctx context.Context, repoURL string) (*models.RepoMetadata, error)
+}
+
+// GitHubFetcher reads repository metadata from the GitHub REST API. BaseURL
+// can point at any server implementing the same endpoints.
+type GitHubFetcher struct {
+	BaseURL string
+	Token   string
+	Client  *http.Client
+}
+
+func NewGitHubFetcher() *GitHubFetcher {
+	cfg := config.GetConfig()
+	return &GitHubFetcher{
+		BaseURL: strings.TrimRight(cfg.GitHubAPIBaseURL, "/"),
+		Token:   cfg.GitHubToken,
+		Client:  &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+// ParseGitHubURL extracts the owner and repository name from a github.com URL
+func ParseGitHubURL(repoURL string) (string, string, error) {
+	u, err := url.Parse(strings.TrimSpace(repoURL))
+	if err != nil {
+		return "", "", ErrUnsupportedRepo
+	}
+
+	host := strings.TrimPrefix(strings.ToLower(u.Host), "www.")
+	if host != "github.com" {
+		return "", "", ErrUnsupportedRepo
+	}
+
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", ErrUnsupportedRepo
+	}
+
+	return parts[0], strings.TrimSuffix(parts[1], ".git"), nil
+}
+
+func (f *GitHubFetcher) Fetch(ctx context.Context, repoURL string) (*models.RepoMetadata, error) {
+	owner, name, err := ParseGitHubURL(repoURL)
+	if err != nil {
+		return nil, err
+	}
+	repoPath := "/repos/" + url.PathEscape(owner) + "/" + url.PathEscape(name)
+
+	var repo struct {
+		FullName    string `json:"full_name"`
+		Description string `json:"description"`
+		Stars       int    `json:"stargazers_count"`
+		Forks       int    `json:"forks_count"`
+		Language    string `json:"language"`
+	}
+	if err := f.getJSON(ctx, repoPath, &repo); err != nil {
+		return nil, err
+	}
+
+	metadata := &models.RepoMetadata{
+		Provider:        "github",
+		FullName:        repo.FullName,
+		Description:     repo.Description,
+		Stars:           repo.Stars,
+		Forks:           repo.Forks,
+		PrimaryLanguage: repo.Language,
+		FetchedAt:       time.Now(),
+	}
+
+	// The remaining details are optional; a missing README or an empty
+	// repository should not fail the whole fetch
+	var languages map[string]int64
+	if err := f.getJSON(ctx, repoPath+"/languages", &languages); err == nil {
+		metadata.Languages = languageShares(languages)
+	}
+
+	if readme, err := f.get(ctx, repoPath+"/readme", "application/vnd.github.raw"); err == nil {
+		metadata.ReadmeExcerpt = readmeExcerpt(string(readme))
+	}
+
+	var commits []struct {
+		Commit struct {
+			Committer struct {
+				Date time.Time `json:"date"`
+			} `json:"committer"`
+		} `json:"commit"`
+	}
+	if err := f.getJSON(ctx, repoPath+"/commits?per_page=1", &commits); err == nil && len(commits) > 0 {
+		lastCommit := commits[0].Commit.Committer.Date
+		metadata.LastCommitAt = &lastCommit
+	}
+
+	return metadata, nil
+}
+
+func (f *GitHubFetcher) getJSON(ctx context.Context, path string, v interface{}) error {
+	body, err := f.get(ctx, path, "application/vnd.github+json")
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, v)
+}
+
+func (f *GitHubFetcher) get(ctx context.Context, path, accept string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, f.BaseURL+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", accept)
+	req.Header.Set("User-Agent", "ete-alumni-portal")
+	if f.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+f.Token)
+	}
+
+	resp, err := f.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s: unexpected status %d", path, resp.StatusCode)
+	}
+
+	// README files can be large; we only keep an excerpt
+	return io.ReadAll(io.LimitReader(resp.Body, 1024*1024))
+}
+
+// languageShares converts byte counts into languages sorted by share
+func languageShares(languages map[string]int64) []models.RepoLanguage {
+	var total int64
+	for _, bytes := range languages {
+		total += bytes
+	}
+	if total == 0 {
+		return nil
+	}
+
+	shares := make([]models.RepoLanguage, 0, len(languages))
+	for name, bytes := range languages {
+		percent := float64(bytes) * 100 / float64(total)
+		shares = append(shares, models.RepoLanguage{
+			Name:    name,
+			Bytes:   bytes,
+			Percent: float64(int(percent*10+0.5)) / 10,
+		})
+	}
+	sort.Slice(shares, func(i, j int) bool {
+		if shares[i].Bytes != shares[j].Bytes {
+			return shares[i].Bytes > shares[j].Bytes
+		}
+		return shares[i].Name < shares[j].Name
+	})
+	return shares
+}
+
+// readmeExcerpt returns the start of the README's prose, skipping headings,
+// badges and code blocks
+func readmeExcerpt(readme string) string {
+	var words []string
+	inCode := false
+	for _, line := range strings.Split(readme, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "```") {
+			inCode = !inCode
+			continue
+		}
+		if inCode || line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "![") ||
+			strings.HasPrefix(line, "[![") || strings.HasPrefix(line, "<") {
+			continue
+		}
+		words = append(words, strings.Fields(line)...)
+	}
+
+	excerpt := strings.Join(words, " ")
+	if len(excerpt) <= readmeExcerptLength {
+		return excerpt
+	}
+
+	excerpt = excerpt[:readmeExcerptLength]
+	if i := strings.LastIndex(excerpt, " "); i > 0 {
+		excerpt = excerpt[:i]
+	}
+	return excerpt + "..."
+}
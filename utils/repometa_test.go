@@ -0,0 +1,79 @@
+package utils
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestParseGitHubURL(t *testing.T) {
+	cases := []struct {
+		url   string
+		owner string
+		repo  string
+		ok    bool
+	}{
+		{"https://github.com/octo/portal", "octo", "portal", true},
+		{"https://www.github.com/octo/portal.git", "octo", "portal", true},
+		{"https://github.com/octo/portal/tree/main", "octo", "portal", true},
+		{"https://github.com/octo", "", "", false},
+		{"https://gitlab.com/octo/portal", "", "", false},
+	}
+
+	for _, tc := range cases {
+		owner, repo, err := ParseGitHubURL(tc.url)
+		if tc.ok != (err == nil) || owner != tc.owner || repo != tc.repo {
+			t.Errorf("ParseGitHubURL(%q) = %q, %q, %v", tc.url, owner, repo, err)
+		}
+	}
+}
+
+func TestGitHubFetcherFetch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/octo/portal":
+			w.Write([]byte(`{"full_name":"octo/portal","description":"Alumni portal","stargazers_count":42,"forks_count":3,"language":"Go"}`))
+		case "/repos/octo/portal/languages":
+			w.Write([]byte(`{"Go":900,"HTML":80,"Shell":20}`))
+		case "/repos/octo/portal/readme":
+			w.Write([]byte("# Portal\n\n![badge](x.svg)\n\nA portal for alumni.\n```\ngo run .\n```\nBuilt with Fiber."))
+		case "/repos/octo/portal/commits":
+			w.Write([]byte(`[{"commit":{"committer":{"date":"2024-05-01T10:00:00Z"}}}]`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	fetcher := &GitHubFetcher{BaseURL: server.URL, Client: server.Client()}
+	metadata, err := fetcher.Fetch(context.Background(), "https://github.com/octo/portal")
+	if err != nil {
+		t.Fatalf("Fetch returned error: %v", err)
+	}
+
+	if metadata.FullName != "octo/portal" || metadata.Stars != 42 || metadata.PrimaryLanguage != "Go" {
+		t.Errorf("unexpected repository fields: %+v", metadata)
+	}
+	if len(metadata.Languages) != 3 || metadata.Languages[0].Name != "Go" || metadata.Languages[0].Percent != 90 {
+		t.Errorf("unexpected languages: %+v", metadata.Languages)
+	}
+	if metadata.ReadmeExcerpt != "A portal for alumni. Built with Fiber." {
+		t.Errorf("unexpected README excerpt: %q", metadata.ReadmeExcerpt)
+	}
+	if metadata.LastCommitAt == nil || metadata.LastCommitAt.Year() != 2024 {
+		t.Errorf("unexpected last commit: %v", metadata.LastCommitAt)
+	}
+}
+
+func TestGitHubFetcherMissingRepo(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	fetcher := &GitHubFetcher{BaseURL: server.URL, Client: server.Client()}
+	_, err := fetcher.Fetch(context.Background(), "https://github.com/octo/missing")
+	if err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("expected 404 error, got %v", err)
+	}
+}